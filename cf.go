@@ -7,14 +7,21 @@ import "C"
 // CF represents a handle to a ColumnFamily.
 type CF struct {
 	c *C.rocksdb_column_family_handle_t
+
+	// ttl is the TTL in seconds of a column family of a database opened
+	// with a TTL.
+	ttl int32
 }
 
 // newNativeCF creates a CF object.
 func newNativeCF(c *C.rocksdb_column_family_handle_t) *CF {
-	return &CF{c}
+	cf := &CF{c: c}
+	trackHandle(cf)
+	return cf
 }
 
 // Release calls the destructor of the underlying column family handle.
 func (c *CF) Release() {
 	C.rocksdb_column_family_handle_destroy(c.c)
+	untrackHandle(c)
 }
@@ -0,0 +1,51 @@
+package gorocksdb
+
+// #include <stdlib.h>
+// #include "rocksdb/c.h"
+import "C"
+import "unsafe"
+
+// Checkpoint provides openable snapshots of a database on disk, created by
+// DB.NewCheckpoint. A checkpoint hard links the live SST files of the
+// database into a new directory where possible, so creating one is cheap.
+type Checkpoint struct {
+	c *C.rocksdb_checkpoint_t
+}
+
+// newNativeCheckpoint creates a Checkpoint object.
+func newNativeCheckpoint(c *C.rocksdb_checkpoint_t) *Checkpoint {
+	return &Checkpoint{c}
+}
+
+// NewCheckpoint creates a Checkpoint object for the database, covering all
+// of its column families.
+func (db *DB) NewCheckpoint() (*Checkpoint, error) {
+	var cErr *C.char
+	cCheckpoint := C.rocksdb_checkpoint_object_create(db.c, &cErr)
+	if cErr != nil {
+		return nil, convertErr(cErr)
+	}
+	return newNativeCheckpoint(cCheckpoint), nil
+}
+
+// CreateCheckpoint builds an openable snapshot of the database in dir,
+// which must not exist yet. The resulting directory can be opened like any
+// other database, e.g. with OpenDBForReadOnly or OpenDBForReadOnlyCFs.
+//
+// The memtables are flushed before the checkpoint is taken if the total
+// size of the write ahead log is at least logSizeForFlush bytes; otherwise
+// the live write ahead log files are copied along. Use 0 to always flush.
+func (c *Checkpoint) CreateCheckpoint(dir string, logSizeForFlush uint64) error {
+	var cErr *C.char
+	cDir := C.CString(dir)
+	defer C.free(unsafe.Pointer(cDir))
+	C.rocksdb_checkpoint_create(c.c, cDir, C.uint64_t(logSizeForFlush), &cErr)
+	return convertErr(cErr)
+}
+
+// Release deallocates the Checkpoint object. Checkpoints already created
+// remain on disk.
+func (c *Checkpoint) Release() {
+	C.rocksdb_checkpoint_object_destroy(c.c)
+	c.c = nil
+}
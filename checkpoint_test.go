@@ -0,0 +1,57 @@
+package gorocksdb
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestCheckpoint(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gorocksdb-TestCheckpoint")
+	ensure.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	givenNames := []string{"default", "guide"}
+	opts := NewOptions()
+	defer opts.Release()
+	opts.SetCreateIfMissingColumnFamilies(true)
+	opts.SetCreateIfMissing(true)
+	db, cfh, err := OpenDBCFs(opts, filepath.Join(dir, "db"), givenNames, []*Options{opts, opts})
+	ensure.Nil(t, err)
+	defer db.Release()
+	defer cfh[0].Release()
+	defer cfh[1].Release()
+
+	var (
+		givenKey = []byte("hello")
+		givenVal = []byte("world")
+		wo       = NewWriteOptions()
+		ro       = NewReadOptions()
+	)
+	defer wo.Release()
+	defer ro.Release()
+	ensure.Nil(t, db.PutCF(wo, cfh[1], givenKey, givenVal))
+
+	checkpoint, err := db.NewCheckpoint()
+	ensure.Nil(t, err)
+	defer checkpoint.Release()
+	checkpointDir := filepath.Join(dir, "checkpoint")
+	ensure.Nil(t, checkpoint.CreateCheckpoint(checkpointDir, 0))
+
+	// writes after the checkpoint are not part of it
+	ensure.Nil(t, db.PutCF(wo, cfh[1], givenKey, []byte("later")))
+
+	cdb, ccfh, err := OpenDBForReadOnlyCFs(opts, checkpointDir, givenNames, []*Options{opts, opts}, false)
+	ensure.Nil(t, err)
+	defer cdb.Release()
+	defer ccfh[0].Release()
+	defer ccfh[1].Release()
+
+	v, err := cdb.GetCF(ro, ccfh[1], givenKey)
+	defer v.Release()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v.Data(), givenVal)
+}
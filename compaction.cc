@@ -0,0 +1,31 @@
+#include <string>
+#include <vector>
+
+#include "gorocksdb_internal.h"
+
+using rocksdb::ColumnFamilyHandle;
+using rocksdb::CompactionOptions;
+using rocksdb::CompactRangeOptions;
+using rocksdb::Slice;
+
+// The C API compaction functions do not report errors.
+
+void gorocksdb_compact_range(rocksdb_t* db, rocksdb_column_family_handle_t* cf, rocksdb_compactoptions_t* opts,
+                             const char* start_key, size_t start_key_len, const char* limit_key,
+                             size_t limit_key_len, char** errptr) {
+    // a nil key is the start or end of the key space
+    Slice start(start_key, start_key_len);
+    Slice limit(limit_key, limit_key_len);
+    ColumnFamilyHandle* handle = cf != NULL ? cf->rep : db->rep->DefaultColumnFamily();
+    CompactRangeOptions default_opts;
+    gorocksdb_save_error(errptr, db->rep->CompactRange(opts != NULL ? opts->rep : default_opts, handle,
+                                                       start_key != NULL ? &start : NULL,
+                                                       limit_key != NULL ? &limit : NULL));
+}
+
+void gorocksdb_compact_files(rocksdb_t* db, rocksdb_column_family_handle_t* cf, char** file_names, size_t num_files,
+                             int output_level, char** errptr) {
+    std::vector<std::string> names(file_names, file_names + num_files);
+    ColumnFamilyHandle* handle = cf != NULL ? cf->rep : db->rep->DefaultColumnFamily();
+    gorocksdb_save_error(errptr, db->rep->CompactFiles(CompactionOptions(), handle, names, output_level));
+}
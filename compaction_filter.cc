@@ -0,0 +1,125 @@
+#include <memory>
+#include <string>
+
+#include "rocksdb/compaction_filter.h"
+#include "gorocksdb_internal.h"
+
+extern "C" {
+// Implemented in compaction_filter.go and compaction_filter_factory.go.
+int gorocksdb_compactionfilter_filter(uintptr_t idx, int level, const char* key, size_t key_len, int value_type, const char* val, size_t val_len, char** new_val, size_t* new_val_len, char** skip_until, size_t* skip_until_len);
+char* gorocksdb_compactionfilter_name(uintptr_t idx);
+void gorocksdb_compactionfilter_destruct(uintptr_t idx);
+int64_t gorocksdb_compactionfilterfactory_create_filter(uintptr_t idx, unsigned char is_full_compaction, unsigned char is_manual_compaction, uint32_t cf_id);
+char* gorocksdb_compactionfilterfactory_name(uintptr_t idx);
+}
+
+using rocksdb::CompactionFilter;
+using rocksdb::CompactionFilterFactory;
+using rocksdb::Slice;
+
+// Takes ownership of a string allocated by Go.
+static std::string gorocksdb_take_string(char* s) {
+    std::string str(s);
+    free(s);
+    return str;
+}
+
+struct gorocksdb_compactionfilter_t : public CompactionFilter {
+    // Filters created by a factory are owned by RocksDB and unregistered
+    // from Go when it destroys them.
+    gorocksdb_compactionfilter_t(uintptr_t idx, bool owned)
+        : idx_(idx), owned_(owned), name_(gorocksdb_take_string(gorocksdb_compactionfilter_name(idx))) {}
+
+    ~gorocksdb_compactionfilter_t() override {
+        if (owned_) {
+            gorocksdb_compactionfilter_destruct(idx_);
+        }
+    }
+
+    Decision FilterV2(int level, const Slice& key, ValueType value_type, const Slice& existing_value,
+                      std::string* new_value, std::string* skip_until) const override {
+        // Values of CompactionValueType in compaction_filter.go.
+        int go_value_type;
+        switch (value_type) {
+        case kValue:
+            go_value_type = 0;
+            break;
+        case kMergeOperand:
+            go_value_type = 1;
+            break;
+        default:
+            return Decision::kKeep;
+        }
+
+        char* c_new_value = NULL;
+        size_t new_value_len = 0;
+        char* c_skip_until = NULL;
+        size_t skip_until_len = 0;
+        // Values of CompactionDecision in compaction_filter.go.
+        int decision = gorocksdb_compactionfilter_filter(
+            idx_, level, key.data(), key.size(), go_value_type, existing_value.data(), existing_value.size(),
+            &c_new_value, &new_value_len, &c_skip_until, &skip_until_len);
+        if (c_new_value != NULL) {
+            new_value->assign(c_new_value, new_value_len);
+            free(c_new_value);
+        }
+        if (c_skip_until != NULL) {
+            skip_until->assign(c_skip_until, skip_until_len);
+            free(c_skip_until);
+        }
+        switch (decision) {
+        case 1:
+            return Decision::kRemove;
+        case 2:
+            return Decision::kChangeValue;
+        case 3:
+            return Decision::kRemoveAndSkipUntil;
+        default:
+            return Decision::kKeep;
+        }
+    }
+
+    const char* Name() const override { return name_.c_str(); }
+
+  private:
+    uintptr_t idx_;
+    bool owned_;
+    std::string name_;
+};
+
+gorocksdb_compactionfilter_t* gorocksdb_compactionfilter_create(uintptr_t idx) {
+    return new gorocksdb_compactionfilter_t(idx, false);
+}
+
+void gorocksdb_compactionfilter_destroy(gorocksdb_compactionfilter_t* filter) {
+    delete filter;
+}
+
+void gorocksdb_options_set_compaction_filter(rocksdb_options_t* opts, gorocksdb_compactionfilter_t* filter) {
+    opts->rep.compaction_filter = filter;
+}
+
+class GoCompactionFilterFactory : public CompactionFilterFactory {
+  public:
+    explicit GoCompactionFilterFactory(uintptr_t idx)
+        : idx_(idx), name_(gorocksdb_take_string(gorocksdb_compactionfilterfactory_name(idx))) {}
+
+    std::unique_ptr<CompactionFilter> CreateCompactionFilter(const CompactionFilter::Context& context) override {
+        int64_t filter_idx = gorocksdb_compactionfilterfactory_create_filter(
+            idx_, context.is_full_compaction, context.is_manual_compaction, context.column_family_id);
+        if (filter_idx < 0) {
+            return nullptr;
+        }
+        return std::unique_ptr<CompactionFilter>(new gorocksdb_compactionfilter_t(filter_idx, true));
+    }
+
+    const char* Name() const override { return name_.c_str(); }
+
+  private:
+    uintptr_t idx_;
+    std::string name_;
+};
+
+void gorocksdb_options_set_compaction_filter_factory(rocksdb_options_t* opts, uintptr_t idx) {
+    opts->rep.compaction_filter_factory = std::make_shared<GoCompactionFilterFactory>(idx);
+}
@@ -2,6 +2,10 @@ package gorocksdb
 
 // #include "rocksdb/c.h"
 import "C"
+import (
+	"sync"
+	"sync/atomic"
+)
 
 // A CompactionFilter can be used to filter keys during compaction time.
 type CompactionFilter interface {
@@ -18,7 +22,8 @@ type CompactionFilter interface {
 	// If multithreaded compaction is being used *and* a single CompactionFilter
 	// instance was supplied via SetCompactionFilter, this the Filter function may be
 	// called from different threads concurrently. The application must ensure
-	// that the call is thread-safe.
+	// that the call is thread-safe. Filters created by a CompactionFilterFactory
+	// are only used by a single compaction at a time.
 	Filter(level int, key, val []byte) (remove bool, newVal []byte)
 
 	// The name of the compaction filter, for logging
@@ -39,31 +44,131 @@ func (c nativeCompactionFilter) Filter(level int, key, val []byte) (remove bool,
 }
 func (c nativeCompactionFilter) Name() string { return "" }
 
-// Hold references to compaction filters.
-var compactionFilters []CompactionFilter
+// CompactionValueType is the type of a value seen by a CompactionFilterV2.
+type CompactionValueType int
 
-func registerCompactionFilter(filter CompactionFilter) int {
-	compactionFilters = append(compactionFilters, filter)
-	return len(compactionFilters) - 1
+// Types of compaction values.
+const (
+	// CompactionValueTypeValue is a value written with Put.
+	CompactionValueTypeValue = CompactionValueType(0)
+	// CompactionValueTypeMergeOperand is an operand written with Merge.
+	CompactionValueTypeMergeOperand = CompactionValueType(1)
+)
+
+// CompactionDecision is the decision of a CompactionFilterV2 for a value.
+type CompactionDecision int
+
+// Compaction decisions.
+const (
+	// CompactionDecisionKeep keeps the value.
+	CompactionDecisionKeep = CompactionDecision(0)
+	// CompactionDecisionRemove removes the value.
+	CompactionDecisionRemove = CompactionDecision(1)
+	// CompactionDecisionChangeValue replaces the value with a new value.
+	CompactionDecisionChangeValue = CompactionDecision(2)
+	// CompactionDecisionRemoveAndSkipUntil removes all keys from the
+	// current key up to, but not including, a given key without passing
+	// them to the filter, which is much cheaper than removing them one by
+	// one. Older versions of the removed keys may reappear, and the keys
+	// are removed even from existing snapshots.
+	CompactionDecisionRemoveAndSkipUntil = CompactionDecision(3)
+)
+
+// A CompactionFilterV2 can be used to filter keys during compaction time.
+// Unlike a CompactionFilter it also sees merge operands, and it can skip
+// over ranges of keys.
+//
+// A CompactionFilter given to SetCompactionFilter or created by a
+// CompactionFilterFactory which also implements CompactionFilterV2 is only
+// called through FilterV2.
+type CompactionFilterV2 interface {
+	// FilterV2 decides what to do with a value of the given type. The
+	// returned newVal is used with CompactionDecisionChangeValue, and
+	// skipUntil, which must be greater than key, with
+	// CompactionDecisionRemoveAndSkipUntil.
+	//
+	// The same thread safety rules apply as for CompactionFilter.Filter.
+	FilterV2(level int, key []byte, valueType CompactionValueType, val []byte) (decision CompactionDecision, newVal, skipUntil []byte)
+
+	// The name of the compaction filter, for logging
+	Name() string
+}
+
+// NewCompactionFilterAdapter returns a CompactionFilterV2 which calls
+// filter for values and keeps all merge operands.
+func NewCompactionFilterAdapter(filter CompactionFilter) CompactionFilterV2 {
+	return compactionFilterAdapter{filter}
+}
+
+type compactionFilterAdapter struct {
+	CompactionFilter
+}
+
+func (a compactionFilterAdapter) FilterV2(level int, key []byte, valueType CompactionValueType, val []byte) (CompactionDecision, []byte, []byte) {
+	if valueType != CompactionValueTypeValue {
+		return CompactionDecisionKeep, nil, nil
+	}
+	remove, newVal := a.Filter(level, key, val)
+	if remove {
+		return CompactionDecisionRemove, nil, nil
+	} else if newVal != nil {
+		return CompactionDecisionChangeValue, newVal, nil
+	}
+	return CompactionDecisionKeep, nil, nil
+}
+
+// toCompactionFilterV2 returns filter itself if it implements
+// CompactionFilterV2, or an adapter otherwise.
+func toCompactionFilterV2(filter CompactionFilter) CompactionFilterV2 {
+	if v2, ok := filter.(CompactionFilterV2); ok {
+		return v2
+	}
+	return NewCompactionFilterAdapter(filter)
+}
+
+// Hold references to compaction filters. Filters created by a
+// CompactionFilterFactory are registered and unregistered concurrently from
+// the background threads of RocksDB.
+var (
+	compactionFilters    sync.Map // map[int]CompactionFilterV2
+	compactionFiltersLen int64
+)
+
+func registerCompactionFilter(filter CompactionFilterV2) int {
+	idx := int(atomic.AddInt64(&compactionFiltersLen, 1) - 1)
+	compactionFilters.Store(idx, filter)
+	return idx
+}
+
+func getCompactionFilter(idx C.uintptr_t) CompactionFilterV2 {
+	filter, _ := compactionFilters.Load(int(idx))
+	return filter.(CompactionFilterV2)
 }
 
 //export gorocksdb_compactionfilter_filter
-func gorocksdb_compactionfilter_filter(idx int, cLevel C.int, cKey *C.char, cKeyLen C.size_t, cVal *C.char, cValLen C.size_t, cNewVal **C.char, cNewValLen *C.size_t, cValChanged *C.uchar) C.int {
+func gorocksdb_compactionfilter_filter(idx C.uintptr_t, cLevel C.int, cKey *C.char, cKeyLen C.size_t, cValueType C.int, cVal *C.char, cValLen C.size_t, cNewVal **C.char, cNewValLen *C.size_t, cSkipUntil **C.char, cSkipUntilLen *C.size_t) C.int {
 	key := charToByte(cKey, cKeyLen)
 	val := charToByte(cVal, cValLen)
 
-	remove, newVal := compactionFilters[idx].Filter(int(cLevel), key, val)
-	if remove {
-		return C.int(1)
-	} else if newVal != nil {
-		*cNewVal = byteToChar(newVal)
+	decision, newVal, skipUntil := getCompactionFilter(idx).FilterV2(int(cLevel), key, CompactionValueType(cValueType), val)
+	switch decision {
+	case CompactionDecisionChangeValue:
+		*cNewVal = cByteSlice(newVal)
 		*cNewValLen = C.size_t(len(newVal))
-		*cValChanged = C.uchar(1)
+	case CompactionDecisionRemoveAndSkipUntil:
+		*cSkipUntil = cByteSlice(skipUntil)
+		*cSkipUntilLen = C.size_t(len(skipUntil))
 	}
-	return C.int(0)
+	return C.int(decision)
 }
 
 //export gorocksdb_compactionfilter_name
-func gorocksdb_compactionfilter_name(idx int) *C.char {
-	return stringToChar(compactionFilters[idx].Name())
+func gorocksdb_compactionfilter_name(idx C.uintptr_t) *C.char {
+	// the C++ wrapper keeps a copy of the name and frees this one
+	return C.CString(getCompactionFilter(idx).Name())
+}
+
+//export gorocksdb_compactionfilter_destruct
+func gorocksdb_compactionfilter_destruct(idx C.uintptr_t) {
+	compactionFilters.Delete(int(idx))
 }
@@ -0,0 +1,69 @@
+package gorocksdb
+
+// #include "gorocksdb.h"
+import "C"
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// A CompactionFilterFactory creates a new CompactionFilter for every
+// compaction run, which allows filters to keep state for the duration of a
+// run without synchronization.
+type CompactionFilterFactory interface {
+	// CreateCompactionFilter returns the filter to use for a compaction, or
+	// nil to not filter it. The filter may also implement CompactionFilterV2.
+	CreateCompactionFilter(ctx CompactionFilterContext) CompactionFilter
+
+	// The name of the compaction filter factory, for logging
+	Name() string
+}
+
+// CompactionFilterContext describes the compaction a filter is created for.
+type CompactionFilterContext struct {
+	// IsFullCompaction is true if the compaction includes all files.
+	IsFullCompaction bool
+	// IsManualCompaction is true if the compaction was requested with
+	// CompactRange.
+	IsManualCompaction bool
+	// ColumnFamilyID is the id of the column family being compacted.
+	ColumnFamilyID uint32
+}
+
+// Hold references to compaction filter factories. They are read from the
+// background threads of RocksDB while other options may register new ones.
+var (
+	compactionFilterFactories    sync.Map // map[int]CompactionFilterFactory
+	compactionFilterFactoriesLen int64
+)
+
+func registerCompactionFilterFactory(factory CompactionFilterFactory) int {
+	idx := int(atomic.AddInt64(&compactionFilterFactoriesLen, 1) - 1)
+	compactionFilterFactories.Store(idx, factory)
+	return idx
+}
+
+func getCompactionFilterFactory(idx C.uintptr_t) CompactionFilterFactory {
+	factory, _ := compactionFilterFactories.Load(int(idx))
+	return factory.(CompactionFilterFactory)
+}
+
+//export gorocksdb_compactionfilterfactory_create_filter
+func gorocksdb_compactionfilterfactory_create_filter(idx C.uintptr_t, cIsFull, cIsManual C.uchar, cCFID C.uint32_t) C.int64_t {
+	filter := getCompactionFilterFactory(idx).CreateCompactionFilter(CompactionFilterContext{
+		IsFullCompaction:   cIsFull != 0,
+		IsManualCompaction: cIsManual != 0,
+		ColumnFamilyID:     uint32(cCFID),
+	})
+	if filter == nil {
+		return -1
+	}
+	// the filter is unregistered when RocksDB destroys it at the end of the run
+	return C.int64_t(registerCompactionFilter(toCompactionFilterV2(filter)))
+}
+
+//export gorocksdb_compactionfilterfactory_name
+func gorocksdb_compactionfilterfactory_name(idx C.uintptr_t) *C.char {
+	// the C++ wrapper keeps a copy of the name and frees this one
+	return C.CString(getCompactionFilterFactory(idx).Name())
+}
@@ -36,7 +36,7 @@ func TestCompactionFilter(t *testing.T) {
 	ensure.Nil(t, db.Put(wo, deleteKey, changeValNew))
 
 	// trigger a compaction
-	db.CompactRange(Range{nil, nil})
+	ensure.Nil(t, db.CompactRange(Range{nil, nil}))
 
 	// ensure that the value is changed after compaction
 	ro := NewReadOptions()
@@ -59,3 +59,158 @@ func (m *mockCompactionFilter) Name() string { return "gorocksdb.test" }
 func (m *mockCompactionFilter) Filter(level int, key, val []byte) (bool, []byte) {
 	return m.filter(level, key, val)
 }
+
+func TestCompactionFilterFactory(t *testing.T) {
+	var (
+		keepKey   = []byte("keep")
+		deleteKey = []byte("delete")
+		contexts  []CompactionFilterContext
+		counts    []int
+	)
+	db := newTestDB(t, "TestCompactionFilterFactory", func(opts *Options) {
+		opts.SetCompactionFilterFactory(&mockCompactionFilterFactory{
+			create: func(ctx CompactionFilterContext) CompactionFilter {
+				// the count is owned by this run and needs no synchronization
+				run := len(counts)
+				contexts = append(contexts, ctx)
+				counts = append(counts, 0)
+				return &mockCompactionFilter{
+					filter: func(level int, key, val []byte) (bool, []byte) {
+						counts[run]++
+						return bytes.Equal(key, deleteKey), nil
+					},
+				}
+			},
+		})
+	})
+	defer db.Release()
+
+	wo := NewWriteOptions()
+	defer wo.Release()
+	ensure.Nil(t, db.Put(wo, keepKey, []byte("val")))
+	ensure.Nil(t, db.Put(wo, deleteKey, []byte("val")))
+	ensure.Nil(t, db.CompactRange(Range{nil, nil}))
+
+	ensure.DeepEqual(t, contexts, []CompactionFilterContext{
+		{IsFullCompaction: true, IsManualCompaction: true, ColumnFamilyID: 0},
+	})
+	ensure.DeepEqual(t, counts, []int{2})
+
+	ro := NewReadOptions()
+	defer ro.Release()
+	v1, err := db.Get(ro, keepKey)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v1.Data(), []byte("val"))
+	v1.Release()
+	v2, err := db.Get(ro, deleteKey)
+	ensure.Nil(t, err)
+	ensure.True(t, v2.Data() == nil)
+}
+
+type mockCompactionFilterFactory struct {
+	create func(ctx CompactionFilterContext) CompactionFilter
+}
+
+func (m *mockCompactionFilterFactory) Name() string { return "gorocksdb.test" }
+func (m *mockCompactionFilterFactory) CreateCompactionFilter(ctx CompactionFilterContext) CompactionFilter {
+	return m.create(ctx)
+}
+
+func TestCompactionFilterV2(t *testing.T) {
+	var operands [][]byte
+	db := newTestDB(t, "TestCompactionFilterV2", func(opts *Options) {
+		opts.SetMergeOperator(&mockMergeOperator{
+			fullMerge: func(key, existingValue []byte, operands [][]byte) ([]byte, bool) {
+				return bytes.Join(append([][]byte{existingValue}, operands...), nil), true
+			},
+			partialMerge: func(key, leftOperand, rightOperand []byte) ([]byte, bool) {
+				return nil, false
+			},
+		})
+		opts.SetCompactionFilterV2(&mockCompactionFilterV2{
+			filter: func(level int, key []byte, valueType CompactionValueType, val []byte) (CompactionDecision, []byte, []byte) {
+				if valueType == CompactionValueTypeMergeOperand {
+					operands = append(operands, append([]byte(nil), val...))
+					if bytes.Equal(val, []byte("drop")) {
+						return CompactionDecisionRemove, nil, nil
+					}
+					return CompactionDecisionKeep, nil, nil
+				}
+				switch string(key) {
+				case "a1":
+					return CompactionDecisionRemoveAndSkipUntil, nil, []byte("a3")
+				case "c":
+					return CompactionDecisionChangeValue, []byte("new"), nil
+				}
+				return CompactionDecisionKeep, nil, nil
+			},
+		})
+	})
+	defer db.Release()
+
+	wo := NewWriteOptions()
+	defer wo.Release()
+	for _, k := range []string{"a1", "a2", "a3", "c"} {
+		ensure.Nil(t, db.Put(wo, []byte(k), []byte("val")))
+	}
+	ensure.Nil(t, db.Merge(wo, []byte("m"), []byte("keep")))
+	ensure.Nil(t, db.Merge(wo, []byte("m"), []byte("drop")))
+	ensure.Nil(t, db.CompactRange(Range{nil, nil}))
+
+	ensure.SameElements(t, operands, [][]byte{[]byte("keep"), []byte("drop")})
+	ro := NewReadOptions()
+	defer ro.Release()
+	for k, expected := range map[string][]byte{
+		"a1": nil,
+		"a2": nil,
+		"a3": []byte("val"),
+		"c":  []byte("new"),
+		"m":  []byte("keep"),
+	} {
+		v, err := db.Get(ro, []byte(k))
+		ensure.Nil(t, err)
+		ensure.DeepEqual(t, v.Data(), expected, k)
+		v.Release()
+	}
+}
+
+func TestCompactionFilterAdapter(t *testing.T) {
+	filter := NewCompactionFilterAdapter(&mockCompactionFilter{
+		filter: func(level int, key, val []byte) (bool, []byte) {
+			switch string(key) {
+			case "remove":
+				return true, nil
+			case "change":
+				return false, []byte("new")
+			case "operand":
+				t.Error("merge operands must not be filtered")
+			}
+			return false, nil
+		},
+	})
+	for _, c := range []struct {
+		key       string
+		valueType CompactionValueType
+		decision  CompactionDecision
+		newVal    []byte
+	}{
+		{"keep", CompactionValueTypeValue, CompactionDecisionKeep, nil},
+		{"remove", CompactionValueTypeValue, CompactionDecisionRemove, nil},
+		{"change", CompactionValueTypeValue, CompactionDecisionChangeValue, []byte("new")},
+		{"operand", CompactionValueTypeMergeOperand, CompactionDecisionKeep, nil},
+	} {
+		decision, newVal, skipUntil := filter.FilterV2(0, []byte(c.key), c.valueType, []byte("val"))
+		ensure.DeepEqual(t, decision, c.decision, c.key)
+		ensure.DeepEqual(t, newVal, c.newVal, c.key)
+		ensure.True(t, skipUntil == nil, c.key)
+	}
+}
+
+type mockCompactionFilterV2 struct {
+	filter func(level int, key []byte, valueType CompactionValueType, val []byte) (CompactionDecision, []byte, []byte)
+}
+
+func (m *mockCompactionFilterV2) Name() string { return "gorocksdb.test" }
+func (m *mockCompactionFilterV2) FilterV2(level int, key []byte, valueType CompactionValueType, val []byte) (CompactionDecision, []byte, []byte) {
+	return m.filter(level, key, valueType, val)
+}
@@ -39,6 +39,43 @@ func TestComparator(t *testing.T) {
 	ensure.DeepEqual(t, actualKeys, givenKeys)
 }
 
+func TestComparatorSeekForPrev(t *testing.T) {
+	db := newTestDB(t, "TestComparatorSeekForPrev", func(opts *Options) {
+		opts.SetComparator(&bytesReverseComparator{})
+	})
+	defer db.Release()
+
+	wo := NewWriteOptions()
+	defer wo.Release()
+	for _, k := range []string{"a1", "b1", "b2", "b3", "c1"} {
+		ensure.Nil(t, db.Put(wo, []byte(k), []byte("val")))
+	}
+
+	ro := NewReadOptions()
+	defer ro.Release()
+	iter := db.NewIterator(ro)
+	defer iter.Release()
+
+	// in reverse order the previous key is the next larger one
+	iter.SeekForPrev([]byte("b25"))
+	ensure.True(t, iter.Valid())
+	ensure.DeepEqual(t, iter.Key().Data(), []byte("b3"))
+	iter.SeekForPrev([]byte("a"))
+	ensure.DeepEqual(t, iter.Key().Data(), []byte("a1"))
+	iter.SeekForPrev([]byte("z"))
+	ensure.False(t, iter.Valid())
+
+	ensure.DeepEqual(t, collectReversePrefix(iter, []byte("b")), []string{"b1", "b2", "b3"})
+	ensure.DeepEqual(t, collectReversePrefix(iter, []byte("a")), []string{"a1"})
+	// a key equal to the successor of the prefix
+	ensure.Nil(t, db.Put(wo, []byte("c"), []byte("val")))
+	iter2 := db.NewIterator(ro)
+	defer iter2.Release()
+	ensure.DeepEqual(t, collectReversePrefix(iter2, []byte("b")), []string{"b1", "b2", "b3"})
+	ensure.DeepEqual(t, collectReversePrefix(iter, []byte("d")), []string(nil))
+	ensure.Nil(t, iter.Err())
+}
+
 type bytesReverseComparator struct{}
 
 func (cmp *bytesReverseComparator) Name() string { return "gorocksdb.bytes-reverse" }
@@ -0,0 +1,9 @@
+#include "gorocksdb_internal.h"
+
+// The C API only deletes ranges of an explicit column family.
+
+void gorocksdb_delete_range(rocksdb_t* db, const rocksdb_writeoptions_t* opts, const char* start_key,
+                            size_t start_key_len, const char* end_key, size_t end_key_len, char** errptr) {
+    rocksdb_column_family_handle_t cf = {db->rep->DefaultColumnFamily()};
+    rocksdb_delete_range_cf(db, opts, &cf, start_key, start_key_len, end_key, end_key_len, errptr);
+}
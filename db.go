@@ -2,9 +2,12 @@ package gorocksdb
 
 // #include <stdlib.h>
 // #include "rocksdb/c.h"
+// #include "gorocksdb.h"
 import "C"
 import (
 	"errors"
+	"strconv"
+	"strings"
 	"unsafe"
 )
 
@@ -18,6 +21,19 @@ type Range struct {
 // DB is a reusable handle to a RocksDB database on disk, created by Open.
 type DB struct {
 	c *C.rocksdb_t
+
+	// ttlBase is the database wrapped by a database opened with a TTL, used
+	// to read the timestamps of values, and ttl the TTL of its default
+	// column family in seconds.
+	ttlBase *C.rocksdb_t
+	ttl     int32
+}
+
+// newNativeDB creates a DB object.
+func newNativeDB(c *C.rocksdb_t) *DB {
+	db := &DB{c: c}
+	trackHandle(db)
+	return db
 }
 
 // OpenDB opens a database with the specified options.
@@ -29,7 +45,7 @@ func OpenDB(opts *Options, name string) (*DB, error) {
 	if cErr != nil {
 		return nil, convertErr(cErr)
 	}
-	return &DB{c: db}, nil
+	return newNativeDB(db), nil
 }
 
 // OpenDBForReadOnly opens a database with the specified options for readonly usage.
@@ -41,7 +57,7 @@ func OpenDBForReadOnly(opts *Options, name string, errorIfLogFileExist bool) (*D
 	if cErr != nil {
 		return nil, convertErr(cErr)
 	}
-	return &DB{c: db}, nil
+	return newNativeDB(db), nil
 }
 
 // OpenDBCFs opens a database with the specified column families.
@@ -95,7 +111,7 @@ func OpenDBCFs(
 		cfHandles[i] = newNativeCF(c)
 	}
 
-	return &DB{c: db}, cfHandles, nil
+	return newNativeDB(db), cfHandles, nil
 }
 
 // OpenDBForReadOnlyCFs opens a database with the specified column
@@ -152,7 +168,7 @@ func OpenDBForReadOnlyCFs(
 		cfHandles[i] = newNativeCF(c)
 	}
 
-	return &DB{c: db}, cfHandles, nil
+	return newNativeDB(db), cfHandles, nil
 }
 
 // ListCFs lists the names of the column families in the DB.
@@ -177,6 +193,9 @@ func ListCFs(opts *Options, name string) ([]string, error) {
 
 // Get returns the data associated with the key from the database.
 func (db *DB) Get(opts *ReadOptions, key []byte) (*Slice, error) {
+	if db.hideExpired(opts) {
+		return db.getUnexpired(opts, nil, key)
+	}
 	var cValLen C.size_t
 	var cErr *C.char
 	cValue := C.rocksdb_get(
@@ -189,6 +208,9 @@ func (db *DB) Get(opts *ReadOptions, key []byte) (*Slice, error) {
 
 // GetCF returns the data associated with the key from the database and column family.
 func (db *DB) GetCF(opts *ReadOptions, cf *CF, key []byte) (*Slice, error) {
+	if db.hideExpired(opts) {
+		return db.getUnexpired(opts, cf, key)
+	}
 	var (
 		cErr    *C.char
 		cValLen C.size_t
@@ -201,6 +223,228 @@ func (db *DB) GetCF(opts *ReadOptions, cf *CF, key []byte) (*Slice, error) {
 	return newSlice(cValue, cValLen), nil
 }
 
+// GetPinned returns the data associated with the key from the database
+// without copying it. The handle must be released to unpin the data.
+func (db *DB) GetPinned(opts *ReadOptions, key []byte) (*PinnableSliceHandle, error) {
+	if db.hideExpired(opts) {
+		return db.getPinnedUnexpired(opts, nil, key)
+	}
+	var cErr *C.char
+	cHandle := C.rocksdb_get_pinned(db.c, opts.c, byteToChar(key), C.size_t(len(key)), &cErr)
+	if cErr != nil {
+		return nil, convertErr(cErr)
+	}
+	return newNativePinnableSliceHandle(cHandle), nil
+}
+
+// GetPinnedCF returns the data associated with the key from the database
+// and column family without copying it. The handle must be released to
+// unpin the data.
+func (db *DB) GetPinnedCF(opts *ReadOptions, cf *CF, key []byte) (*PinnableSliceHandle, error) {
+	if db.hideExpired(opts) {
+		return db.getPinnedUnexpired(opts, cf, key)
+	}
+	var cErr *C.char
+	cHandle := C.rocksdb_get_pinned_cf(db.c, opts.c, cf.c, byteToChar(key), C.size_t(len(key)), &cErr)
+	if cErr != nil {
+		return nil, convertErr(cErr)
+	}
+	return newNativePinnableSliceHandle(cHandle), nil
+}
+
+// GetBytes returns a copy of the data associated with the key from the
+// database, or nil if the key was not found. Unlike Get, the result is
+// owned by Go and needs no release.
+func (db *DB) GetBytes(opts *ReadOptions, key []byte) ([]byte, error) {
+	value, _, err := db.GetInto(opts, key, nil)
+	return value, err
+}
+
+// GetBytesCF returns a copy of the data associated with the key from the
+// database and column family, or nil if the key was not found.
+func (db *DB) GetBytesCF(opts *ReadOptions, cf *CF, key []byte) ([]byte, error) {
+	value, _, err := db.GetIntoCF(opts, cf, key, nil)
+	return value, err
+}
+
+// GetInto appends the data associated with the key from the database to dst
+// and returns the extended buffer, which allows reusing a buffer across
+// lookups. The bool reports whether the key was found; if not, dst is
+// returned unchanged.
+func (db *DB) GetInto(opts *ReadOptions, key []byte, dst []byte) ([]byte, bool, error) {
+	h, err := db.GetPinned(opts, key)
+	if err != nil {
+		return dst, false, err
+	}
+	return appendPinned(dst, h)
+}
+
+// GetIntoCF appends the data associated with the key from the database and
+// column family to dst. See GetInto.
+func (db *DB) GetIntoCF(opts *ReadOptions, cf *CF, key []byte, dst []byte) ([]byte, bool, error) {
+	h, err := db.GetPinnedCF(opts, cf, key)
+	if err != nil {
+		return dst, false, err
+	}
+	return appendPinned(dst, h)
+}
+
+// appendPinned appends the value of h to dst and releases h.
+func appendPinned(dst []byte, h *PinnableSliceHandle) ([]byte, bool, error) {
+	defer h.Release()
+	if !h.Exists() {
+		return dst, false, nil
+	}
+	if dst == nil {
+		// distinguish empty values from missing keys
+		dst = []byte{}
+	}
+	return append(dst, h.Data()...), true, nil
+}
+
+// KeyMayExist reports whether the key may exist in the database, using only
+// data in memory such as the memtables and the bloom filters set with
+// BlockBasedTableOptions.SetFilterPolicy. A false result means the key does
+// not exist, so the lookup can be skipped; a true result may be a false
+// positive.
+func (db *DB) KeyMayExist(opts *ReadOptions, key []byte) bool {
+	return C.rocksdb_key_may_exist(db.c, opts.c, byteToChar(key), C.size_t(len(key)), nil, nil, nil, 0, nil) != 0
+}
+
+// KeyMayExistCF reports whether the key may exist in the database and
+// column family. See KeyMayExist.
+func (db *DB) KeyMayExistCF(opts *ReadOptions, cf *CF, key []byte) bool {
+	return C.rocksdb_key_may_exist_cf(db.c, opts.c, cf.c, byteToChar(key), C.size_t(len(key)), nil, nil, nil, 0, nil) != 0
+}
+
+// MultiGet returns the data associated with each of the keys from a
+// consistent view of the database, resolving all keys in a single call.
+// The returned slices are parallel to keys: the Slice is nil if the key was
+// not found, and the error is set if the lookup of that key failed.
+func (db *DB) MultiGet(opts *ReadOptions, keys [][]byte) ([]*Slice, []error) {
+	numKeys := len(keys)
+	if numKeys == 0 {
+		return nil, nil
+	}
+	cKeys, cKeySizes := multiGetKeys(keys)
+	defer freeMultiGetKeys(cKeys)
+
+	cDB := db.c
+	if db.hideExpired(opts) {
+		cDB = db.ttlBase
+	}
+	cValues := make([]*C.char, numKeys)
+	cValueSizes := make([]C.size_t, numKeys)
+	cErrs := make([]*C.char, numKeys)
+	C.rocksdb_multi_get(
+		cDB,
+		opts.c,
+		C.size_t(numKeys),
+		&cKeys[0],
+		&cKeySizes[0],
+		&cValues[0],
+		&cValueSizes[0],
+		&cErrs[0],
+	)
+	if cDB == db.ttlBase {
+		expiry := newTTLExpiry(db.ttl)
+		for i := range cValues {
+			hideExpiredMultiGetValue(expiry, &cValues[i], &cValueSizes[i], cErrs[i])
+		}
+	}
+	return multiGetResults(cValues, cValueSizes, cErrs)
+}
+
+// MultiGetCF returns the data associated with each of the keys from a
+// consistent view of the database like MultiGet. cfs is parallel to keys
+// and names the column family each key is looked up in.
+func (db *DB) MultiGetCF(opts *ReadOptions, cfs []*CF, keys [][]byte) ([]*Slice, []error) {
+	numKeys := len(keys)
+	if numKeys != len(cfs) {
+		err := errors.New("must provide the same number of column families and keys")
+		errs := make([]error, numKeys)
+		for i := range errs {
+			errs[i] = err
+		}
+		return make([]*Slice, numKeys), errs
+	}
+	if numKeys == 0 {
+		return nil, nil
+	}
+	cKeys, cKeySizes := multiGetKeys(keys)
+	defer freeMultiGetKeys(cKeys)
+
+	cCFs := make([]*C.rocksdb_column_family_handle_t, numKeys)
+	for i, cf := range cfs {
+		cCFs[i] = cf.c
+	}
+
+	cDB := db.c
+	if db.hideExpired(opts) {
+		cDB = db.ttlBase
+	}
+	cValues := make([]*C.char, numKeys)
+	cValueSizes := make([]C.size_t, numKeys)
+	cErrs := make([]*C.char, numKeys)
+	C.rocksdb_multi_get_cf(
+		cDB,
+		opts.c,
+		&cCFs[0],
+		C.size_t(numKeys),
+		&cKeys[0],
+		&cKeySizes[0],
+		&cValues[0],
+		&cValueSizes[0],
+		&cErrs[0],
+	)
+	if cDB == db.ttlBase {
+		expiries := make(map[*CF]*ttlExpiry)
+		for i, cf := range cfs {
+			if expiries[cf] == nil {
+				expiries[cf] = newTTLExpiry(cf.ttl)
+			}
+			hideExpiredMultiGetValue(expiries[cf], &cValues[i], &cValueSizes[i], cErrs[i])
+		}
+	}
+	return multiGetResults(cValues, cValueSizes, cErrs)
+}
+
+// multiGetKeys copies the keys into C memory, as the list of keys passed to
+// C must not contain Go pointers.
+func multiGetKeys(keys [][]byte) ([]*C.char, []C.size_t) {
+	cKeys := make([]*C.char, len(keys))
+	cKeySizes := make([]C.size_t, len(keys))
+	for i, k := range keys {
+		cKeys[i] = cByteSlice(k)
+		cKeySizes[i] = C.size_t(len(k))
+	}
+	return cKeys, cKeySizes
+}
+
+// freeMultiGetKeys frees the keys allocated by multiGetKeys.
+func freeMultiGetKeys(cKeys []*C.char) {
+	for _, k := range cKeys {
+		C.free(unsafe.Pointer(k))
+	}
+}
+
+// multiGetResults converts the values and errors returned by a multi get.
+func multiGetResults(cValues []*C.char, cValueSizes []C.size_t, cErrs []*C.char) ([]*Slice, []error) {
+	values := make([]*Slice, len(cValues))
+	errs := make([]error, len(cValues))
+	for i, cValue := range cValues {
+		if cErrs[i] != nil {
+			C.free(unsafe.Pointer(cValue))
+			errs[i] = convertErr(cErrs[i])
+			continue
+		}
+		if cValue != nil {
+			values[i] = newSlice(cValue, cValueSizes[i])
+		}
+	}
+	return values, errs
+}
+
 // Put writes data associated with a key to the database.
 func (db *DB) Put(opts *WriteOptions, key, value []byte) error {
 	var (
@@ -243,6 +487,55 @@ func (db *DB) DeleteCF(opts *WriteOptions, cf *CF, key []byte) error {
 	return convertErr(cErr)
 }
 
+// SingleDelete removes the data associated with the key from the database.
+// It is only correct for keys which were written once since the last
+// deletion and never overwritten or merged; in exchange its tombstone is
+// dropped as soon as it meets the write it deletes during compaction.
+func (db *DB) SingleDelete(opts *WriteOptions, key []byte) error {
+	var (
+		cErr *C.char
+		cKey = byteToChar(key)
+	)
+	C.rocksdb_singledelete(db.c, opts.c, cKey, C.size_t(len(key)), &cErr)
+	return convertErr(cErr)
+}
+
+// SingleDeleteCF removes the data associated with the key from the database
+// and column family. See SingleDelete for the restrictions.
+func (db *DB) SingleDeleteCF(opts *WriteOptions, cf *CF, key []byte) error {
+	var (
+		cErr *C.char
+		cKey = byteToChar(key)
+	)
+	C.rocksdb_singledelete_cf(db.c, opts.c, cf.c, cKey, C.size_t(len(key)), &cErr)
+	return convertErr(cErr)
+}
+
+// DeleteRange removes the data associated with all keys in the range
+// [startKey, endKey) from the database using a single range tombstone.
+func (db *DB) DeleteRange(opts *WriteOptions, startKey, endKey []byte) error {
+	var (
+		cErr      *C.char
+		cStartKey = byteToChar(startKey)
+		cEndKey   = byteToChar(endKey)
+	)
+	C.gorocksdb_delete_range(db.c, opts.c, cStartKey, C.size_t(len(startKey)), cEndKey, C.size_t(len(endKey)), &cErr)
+	return convertErr(cErr)
+}
+
+// DeleteRangeCF removes the data associated with all keys in the range
+// [startKey, endKey) from the database and column family using a single
+// range tombstone.
+func (db *DB) DeleteRangeCF(opts *WriteOptions, cf *CF, startKey, endKey []byte) error {
+	var (
+		cErr      *C.char
+		cStartKey = byteToChar(startKey)
+		cEndKey   = byteToChar(endKey)
+	)
+	C.rocksdb_delete_range_cf(db.c, opts.c, cf.c, cStartKey, C.size_t(len(startKey)), cEndKey, C.size_t(len(endKey)), &cErr)
+	return convertErr(cErr)
+}
+
 // Merge merges the data associated with the key with the actual data in the database.
 func (db *DB) Merge(opts *WriteOptions, key []byte, value []byte) error {
 	var (
@@ -276,6 +569,11 @@ func (db *DB) Write(opts *WriteOptions, batch *WriteBatch) error {
 // NewIterator returns an Iterator over the the database that uses the
 // ReadOptions given.
 func (db *DB) NewIterator(opts *ReadOptions) *Iterator {
+	if db.hideExpired(opts) {
+		iter := newNativeIterator(C.rocksdb_create_iterator(db.ttlBase, opts.c))
+		iter.expiry = newTTLExpiry(db.ttl)
+		return iter
+	}
 	cIter := C.rocksdb_create_iterator(db.c, opts.c)
 	return newNativeIterator(cIter)
 }
@@ -283,6 +581,11 @@ func (db *DB) NewIterator(opts *ReadOptions) *Iterator {
 // NewIteratorCF returns an Iterator over the the database and column family
 // that uses the ReadOptions given.
 func (db *DB) NewIteratorCF(opts *ReadOptions, cf *CF) *Iterator {
+	if db.hideExpired(opts) {
+		iter := newNativeIterator(C.rocksdb_create_iterator_cf(db.ttlBase, opts.c, cf.c))
+		iter.expiry = newTTLExpiry(cf.ttl)
+		return iter
+	}
 	cIter := C.rocksdb_create_iterator_cf(db.c, opts.c, cf.c)
 	return newNativeIterator(cIter)
 }
@@ -296,10 +599,15 @@ func (db *DB) NewIterators(opts *ReadOptions, cfs []*CF) ([]*Iterator, error) {
 		cCF[i] = cfHandle.c
 	}
 
+	cDB := db.c
+	if db.hideExpired(opts) {
+		cDB = db.ttlBase
+	}
+
 	cIters := make([]*C.rocksdb_iterator_t, size)
 	var cErr *C.char
 	C.rocksdb_create_iterators(
-		db.c,
+		cDB,
 		opts.c,
 		&cCF[0],
 		&cIters[0],
@@ -311,8 +619,12 @@ func (db *DB) NewIterators(opts *ReadOptions, cfs []*CF) ([]*Iterator, error) {
 	}
 
 	var iters []*Iterator
-	for _, iter := range cIters {
-		iters = append(iters, newNativeIterator(iter))
+	for i, iter := range cIters {
+		it := newNativeIterator(iter)
+		if cDB == db.ttlBase {
+			it.expiry = newTTLExpiry(cfs[i].ttl)
+		}
+		iters = append(iters, it)
 	}
 	return iters, nil
 }
@@ -341,6 +653,77 @@ func (db *DB) GetPropertyCF(propName string, cf *CF) string {
 	return C.GoString(cValue)
 }
 
+// GetIntProperty returns the value of a numeric database property. It
+// returns false if the property is unknown or not numeric.
+func (db *DB) GetIntProperty(propName string) (uint64, bool) {
+	var cValue C.uint64_t
+	cProp := C.CString(propName)
+	defer C.free(unsafe.Pointer(cProp))
+	if C.rocksdb_property_int(db.c, cProp, &cValue) == 0 {
+		return uint64(cValue), true
+	}
+	// some numeric properties such as PropertyNumFilesAtLevelPrefix are
+	// only available as strings
+	return parseIntProperty(db.GetProperty(propName))
+}
+
+// GetIntPropertyCF returns the value of a numeric database property of a
+// column family. It returns false if the property is unknown or not
+// numeric.
+func (db *DB) GetIntPropertyCF(propName string, cf *CF) (uint64, bool) {
+	var cValue C.uint64_t
+	cProp := C.CString(propName)
+	defer C.free(unsafe.Pointer(cProp))
+	if C.rocksdb_property_int_cf(db.c, cf.c, cProp, &cValue) == 0 {
+		return uint64(cValue), true
+	}
+	return parseIntProperty(db.GetPropertyCF(propName, cf))
+}
+
+// GetMapProperty returns the value of a database property in its map form,
+// such as PropertyCFStats or PropertyAggregatedTableProperties. It returns
+// false if the property is unknown or has no map form.
+func (db *DB) GetMapProperty(propName string) (map[string]string, bool) {
+	return db.getMapProperty(propName, nil)
+}
+
+// GetMapPropertyCF returns the value of a database property of a column
+// family in its map form. See GetMapProperty.
+func (db *DB) GetMapPropertyCF(propName string, cf *CF) (map[string]string, bool) {
+	return db.getMapProperty(propName, cf.c)
+}
+
+func (db *DB) getMapProperty(propName string, cCF *C.rocksdb_column_family_handle_t) (map[string]string, bool) {
+	cProp := stringToChar(propName)
+	p := C.gorocksdb_property_map(db.c, cCF, cProp, C.size_t(len(propName)))
+	if p == nil {
+		return nil, false
+	}
+	defer C.gorocksdb_map_property_destroy(p)
+	n := int(p.num_entries)
+	m := make(map[string]string, n)
+	if n == 0 {
+		return m, true
+	}
+	keys := (*[1 << 24]*C.char)(unsafe.Pointer(p.keys))[:n:n]
+	keyLens := (*[1 << 24]C.size_t)(unsafe.Pointer(p.key_lens))[:n:n]
+	values := (*[1 << 24]*C.char)(unsafe.Pointer(p.values))[:n:n]
+	valueLens := (*[1 << 24]C.size_t)(unsafe.Pointer(p.value_lens))[:n:n]
+	for i := range keys {
+		m[C.GoStringN(keys[i], C.int(keyLens[i]))] = C.GoStringN(values[i], C.int(valueLens[i]))
+	}
+	return m, true
+}
+
+// parseIntProperty parses the string value of a numeric property.
+func parseIntProperty(value string) (uint64, bool) {
+	v, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
 // CreateCF create a new column family.
 func (db *DB) CreateCF(opts *Options, name string) (*CF, error) {
 	var (
@@ -438,47 +821,232 @@ type LiveFileMetadata struct {
 	Size        int64
 	SmallestKey []byte
 	LargestKey  []byte
+
+	// CFName is the name of the column family the file belongs to.
+	CFName         string
+	NumEntries     uint64
+	NumDeletions   uint64
+	SmallestSeqno  uint64
+	LargestSeqno   uint64
+	BeingCompacted bool
+}
+
+// ColumnFamilyMetadata describes the SST files of a column family.
+type ColumnFamilyMetadata struct {
+	Name      string
+	Size      uint64
+	FileCount int
+	// Levels holds every level of the column family, including empty ones.
+	Levels []LevelMetadata
+}
+
+// LevelMetadata describes the SST files of a level of a column family.
+type LevelMetadata struct {
+	Level int
+	Size  uint64
+	Files []LiveFileMetadata
 }
 
 // GetLiveFilesMetaData returns a list of all table files with their
 // level, start key and end key.
 func (db *DB) GetLiveFilesMetaData() []LiveFileMetadata {
-	lf := C.rocksdb_livefiles(db.c)
-	defer C.rocksdb_livefiles_destroy(lf)
+	m := C.gorocksdb_livefiles_metadata(db.c)
+	defer C.gorocksdb_metadata_destroy(m)
+	return sstFilesMetadata(m)
+}
 
-	count := C.rocksdb_livefiles_count(lf)
-	liveFiles := make([]LiveFileMetadata, int(count))
-	for i := C.int(0); i < count; i++ {
-		var liveFile LiveFileMetadata
-		liveFile.Name = C.GoString(C.rocksdb_livefiles_name(lf, i))
-		liveFile.Level = int(C.rocksdb_livefiles_level(lf, i))
-		liveFile.Size = int64(C.rocksdb_livefiles_size(lf, i))
+// GetColumnFamilyMetaData returns the table files of the column family
+// grouped by level. cf may be nil for the default column family.
+func (db *DB) GetColumnFamilyMetaData(cf *CF) ColumnFamilyMetadata {
+	var cCF *C.rocksdb_column_family_handle_t
+	if cf != nil {
+		cCF = cf.c
+	}
+	m := C.gorocksdb_column_family_metadata(db.c, cCF)
+	defer C.gorocksdb_metadata_destroy(m)
 
-		var cSize C.size_t
-		key := C.rocksdb_livefiles_smallestkey(lf, i, &cSize)
-		liveFile.SmallestKey = C.GoBytes(unsafe.Pointer(key), C.int(cSize))
+	files := sstFilesMetadata(m)
+	numLevels := int(m.num_levels)
+	levels := make([]LevelMetadata, numLevels)
+	if numLevels > 0 {
+		levelSizes := (*[1 << 20]C.uint64_t)(unsafe.Pointer(m.level_sizes))[:numLevels:numLevels]
+		for i, size := range levelSizes {
+			levels[i] = LevelMetadata{Level: i, Size: uint64(size)}
+		}
+	}
+	for _, f := range files {
+		if f.Level >= 0 && f.Level < numLevels {
+			levels[f.Level].Files = append(levels[f.Level].Files, f)
+		}
+	}
+	return ColumnFamilyMetadata{
+		Name:      C.GoString(m.cf_name),
+		Size:      uint64(m.size),
+		FileCount: len(files),
+		Levels:    levels,
+	}
+}
 
-		key = C.rocksdb_livefiles_largestkey(lf, i, &cSize)
-		liveFile.LargestKey = C.GoBytes(unsafe.Pointer(key), C.int(cSize))
-		liveFiles[int(i)] = liveFile
+// sstFilesMetadata copies the file metadata out of m.
+func sstFilesMetadata(m *C.gorocksdb_metadata_t) []LiveFileMetadata {
+	numFiles := int(m.num_files)
+	if numFiles == 0 {
+		return nil
 	}
-	return liveFiles
+	cFiles := (*[1 << 24]C.gorocksdb_sstfilemetadata_t)(unsafe.Pointer(m.files))[:numFiles:numFiles]
+	files := make([]LiveFileMetadata, numFiles)
+	for i, f := range cFiles {
+		files[i] = LiveFileMetadata{
+			Name:           C.GoString(f.name),
+			Level:          int(f.level),
+			Size:           int64(f.size),
+			SmallestKey:    C.GoBytes(unsafe.Pointer(f.smallest_key), C.int(f.smallest_key_len)),
+			LargestKey:     C.GoBytes(unsafe.Pointer(f.largest_key), C.int(f.largest_key_len)),
+			CFName:         C.GoString(f.cf_name),
+			NumEntries:     uint64(f.num_entries),
+			NumDeletions:   uint64(f.num_deletions),
+			SmallestSeqno:  uint64(f.smallest_seqno),
+			LargestSeqno:   uint64(f.largest_seqno),
+			BeingCompacted: f.being_compacted != 0,
+		}
+	}
+	return files
 }
 
 // CompactRange runs a manual compaction on the Range of keys given. This is
 // not likely to be needed for typical usage.
-func (db *DB) CompactRange(r Range) {
-	cStart := byteToChar(r.Start)
-	cLimit := byteToChar(r.Limit)
-	C.rocksdb_compact_range(db.c, cStart, C.size_t(len(r.Start)), cLimit, C.size_t(len(r.Limit)))
+func (db *DB) CompactRange(r Range) error {
+	return db.compactRange(nil, nil, r)
 }
 
 // CompactRangeCF runs a manual compaction on the Range of keys given on the
 // given column family. This is not likely to be needed for typical usage.
-func (db *DB) CompactRangeCF(cf *CF, r Range) {
-	cStart := byteToChar(r.Start)
-	cLimit := byteToChar(r.Limit)
-	C.rocksdb_compact_range_cf(db.c, cf.c, cStart, C.size_t(len(r.Start)), cLimit, C.size_t(len(r.Limit)))
+func (db *DB) CompactRangeCF(cf *CF, r Range) error {
+	return db.compactRange(nil, cf, r)
+}
+
+// CompactRangeWithOptions runs a manual compaction on the Range of keys
+// given using the CompactRangeOptions given.
+func (db *DB) CompactRangeWithOptions(opts *CompactRangeOptions, r Range) error {
+	return db.compactRange(opts, nil, r)
+}
+
+// CompactRangeCFWithOptions runs a manual compaction on the Range of keys
+// given on the given column family using the CompactRangeOptions given.
+func (db *DB) CompactRangeCFWithOptions(opts *CompactRangeOptions, cf *CF, r Range) error {
+	return db.compactRange(opts, cf, r)
+}
+
+// compactRange runs a manual compaction, opts and cf may be nil for the
+// default options and column family.
+func (db *DB) compactRange(opts *CompactRangeOptions, cf *CF, r Range) error {
+	var (
+		cErr   *C.char
+		cOpts  *C.rocksdb_compactoptions_t
+		cCF    *C.rocksdb_column_family_handle_t
+		cStart = byteToChar(r.Start)
+		cLimit = byteToChar(r.Limit)
+	)
+	if opts != nil {
+		cOpts = opts.c
+	}
+	if cf != nil {
+		cCF = cf.c
+	}
+	C.gorocksdb_compact_range(db.c, cCF, cOpts, cStart, C.size_t(len(r.Start)), cLimit, C.size_t(len(r.Limit)), &cErr)
+	return convertErr(cErr)
+}
+
+// CompactFiles compacts the given SST files, named as returned by
+// GetLiveFilesMetaData, into outputLevel.
+func (db *DB) CompactFiles(fileNames []string, outputLevel int) error {
+	return db.compactFiles(nil, fileNames, outputLevel)
+}
+
+// CompactFilesCF compacts the given SST files of the column family, named
+// as returned by GetLiveFilesMetaData, into outputLevel.
+func (db *DB) CompactFilesCF(cf *CF, fileNames []string, outputLevel int) error {
+	return db.compactFiles(cf, fileNames, outputLevel)
+}
+
+func (db *DB) compactFiles(cf *CF, fileNames []string, outputLevel int) error {
+	if len(fileNames) == 0 {
+		return errors.New("must provide at least one file to compact")
+	}
+	cFileNames := make([]*C.char, len(fileNames))
+	for i, s := range fileNames {
+		cFileNames[i] = C.CString(s)
+	}
+	defer func() {
+		for _, s := range cFileNames {
+			C.free(unsafe.Pointer(s))
+		}
+	}()
+
+	var (
+		cErr *C.char
+		cCF  *C.rocksdb_column_family_handle_t
+	)
+	if cf != nil {
+		cCF = cf.c
+	}
+	C.gorocksdb_compact_files(db.c, cCF, &cFileNames[0], C.size_t(len(fileNames)), C.int(outputLevel), &cErr)
+	return convertErr(cErr)
+}
+
+// IngestExternalFile loads a list of SST files, e.g. created by a
+// SstFileWriter, into the database.
+func (db *DB) IngestExternalFile(filePaths []string, opts *IngestExternalFileOptions) error {
+	if len(filePaths) == 0 {
+		return errors.New("must provide at least one file to ingest")
+	}
+	cFilePaths := make([]*C.char, len(filePaths))
+	for i, s := range filePaths {
+		cFilePaths[i] = C.CString(s)
+	}
+	defer func() {
+		for _, s := range cFilePaths {
+			C.free(unsafe.Pointer(s))
+		}
+	}()
+
+	var cErr *C.char
+	C.rocksdb_ingest_external_file(
+		db.c,
+		&cFilePaths[0],
+		C.size_t(len(filePaths)),
+		opts.c,
+		&cErr,
+	)
+	return convertErr(cErr)
+}
+
+// IngestExternalFileCF loads a list of SST files, e.g. created by a
+// SstFileWriter, into the column family.
+func (db *DB) IngestExternalFileCF(cf *CF, filePaths []string, opts *IngestExternalFileOptions) error {
+	if len(filePaths) == 0 {
+		return errors.New("must provide at least one file to ingest")
+	}
+	cFilePaths := make([]*C.char, len(filePaths))
+	for i, s := range filePaths {
+		cFilePaths[i] = C.CString(s)
+	}
+	defer func() {
+		for _, s := range cFilePaths {
+			C.free(unsafe.Pointer(s))
+		}
+	}()
+
+	var cErr *C.char
+	C.rocksdb_ingest_external_file_cf(
+		db.c,
+		cf.c,
+		&cFilePaths[0],
+		C.size_t(len(filePaths)),
+		opts.c,
+		&cErr,
+	)
+	return convertErr(cErr)
 }
 
 // Flush triggers a manuel flush for the database.
@@ -513,7 +1081,12 @@ func (db *DB) DeleteFile(name string) {
 
 // Release closes the database.
 func (db *DB) Release() {
+	if db.ttlBase != nil {
+		C.gorocksdb_ttl_base_db_destroy(db.ttlBase)
+		db.ttlBase = nil
+	}
 	C.rocksdb_close(db.c)
+	untrackHandle(db)
 }
 
 // DestroyDB removes a database entirely, removing everything from the
@@ -1,6 +1,7 @@
 package gorocksdb
 
 import (
+	"bytes"
 	"io/ioutil"
 	"testing"
 
@@ -61,3 +62,325 @@ func newTestDB(t *testing.T, name string, applyOpts func(opts *Options)) *DB {
 
 	return db
 }
+
+func TestDBMultiGet(t *testing.T) {
+	db := newTestDB(t, "TestDBMultiGet", nil)
+	defer db.Release()
+
+	var (
+		givenKey1 = []byte("hello1")
+		givenKey2 = []byte("hello2")
+		givenKey3 = []byte("hello3")
+		givenVal1 = []byte("world1")
+		wo        = NewWriteOptions()
+		ro        = NewReadOptions()
+	)
+	defer wo.Release()
+	defer ro.Release()
+	ensure.Nil(t, db.Put(wo, givenKey1, givenVal1))
+	ensure.Nil(t, db.Put(wo, givenKey2, []byte{}))
+
+	values, errs := db.MultiGet(ro, [][]byte{givenKey1, givenKey2, givenKey3})
+	ensure.DeepEqual(t, len(values), 3)
+	ensure.DeepEqual(t, errs, []error{nil, nil, nil})
+	defer values[0].Release()
+	defer values[1].Release()
+
+	ensure.DeepEqual(t, values[0].Data(), givenVal1)
+	ensure.NotNil(t, values[1])
+	ensure.DeepEqual(t, values[1].Size(), 0)
+	ensure.True(t, values[2] == nil)
+}
+
+func TestDBDeleteRange(t *testing.T) {
+	db := newTestDB(t, "TestDBDeleteRange", nil)
+	defer db.Release()
+
+	var (
+		givenKeys = [][]byte{[]byte("key1"), []byte("key2"), []byte("key3")}
+		wo        = NewWriteOptions()
+		ro        = NewReadOptions()
+	)
+	defer wo.Release()
+	defer ro.Release()
+	for _, k := range givenKeys {
+		ensure.Nil(t, db.Put(wo, k, []byte("val")))
+	}
+
+	// the end key is exclusive
+	ensure.Nil(t, db.DeleteRange(wo, givenKeys[0], givenKeys[2]))
+	for _, k := range givenKeys[:2] {
+		v, err := db.Get(ro, k)
+		ensure.Nil(t, err)
+		ensure.True(t, v.Data() == nil)
+	}
+	v, err := db.Get(ro, givenKeys[2])
+	defer v.Release()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v.Data(), []byte("val"))
+}
+
+func TestDBSingleDelete(t *testing.T) {
+	db := newTestDB(t, "TestDBSingleDelete", nil)
+	defer db.Release()
+
+	var (
+		givenKey = []byte("hello")
+		wo       = NewWriteOptions()
+		ro       = NewReadOptions()
+	)
+	defer wo.Release()
+	defer ro.Release()
+	ensure.Nil(t, db.Put(wo, givenKey, []byte("world")))
+	ensure.Nil(t, db.SingleDelete(wo, givenKey))
+	v, err := db.Get(ro, givenKey)
+	ensure.Nil(t, err)
+	ensure.True(t, v.Data() == nil)
+}
+
+func TestDBGetIntProperty(t *testing.T) {
+	db := newTestDB(t, "TestDBGetIntProperty", nil)
+	defer db.Release()
+
+	wo := NewWriteOptions()
+	defer wo.Release()
+	ensure.Nil(t, db.Put(wo, []byte("key1"), []byte("val1")))
+	ensure.Nil(t, db.Put(wo, []byte("key2"), []byte("val2")))
+
+	n, ok := db.GetIntProperty(PropertyNumEntriesActiveMemTable)
+	ensure.True(t, ok)
+	ensure.DeepEqual(t, n, uint64(2))
+
+	// only available as a string
+	n, ok = db.GetIntProperty(PropertyNumFilesAtLevelPrefix + "0")
+	ensure.True(t, ok)
+	ensure.DeepEqual(t, n, uint64(0))
+
+	_, ok = db.GetIntProperty(PropertyStats)
+	ensure.False(t, ok)
+	_, ok = db.GetIntProperty("rocksdb.unknown-property")
+	ensure.False(t, ok)
+}
+
+func TestDBGetMapProperty(t *testing.T) {
+	db := newTestDB(t, "TestDBGetMapProperty", nil)
+	defer db.Release()
+
+	wo := NewWriteOptions()
+	defer wo.Release()
+	ensure.Nil(t, db.Put(wo, []byte("key"), []byte("value")))
+
+	m, ok := db.GetMapProperty(PropertyCFStats)
+	ensure.True(t, ok)
+	ensure.True(t, len(m) > 0)
+
+	opts := NewOptions()
+	defer opts.Release()
+	cf, err := db.CreateCF(opts, "other")
+	ensure.Nil(t, err)
+	defer cf.Release()
+	m, ok = db.GetMapPropertyCF(PropertyCFStats, cf)
+	ensure.True(t, ok)
+	ensure.True(t, len(m) > 0)
+
+	_, ok = db.GetMapProperty("rocksdb.unknown-property")
+	ensure.False(t, ok)
+}
+
+func TestDBCompactRangeWithOptions(t *testing.T) {
+	db := newTestDB(t, "TestDBCompactRangeWithOptions", nil)
+	defer db.Release()
+
+	wo := NewWriteOptions()
+	defer wo.Release()
+	fo := NewFlushOptions()
+	defer fo.Release()
+	ensure.Nil(t, db.Put(wo, []byte("key1"), []byte("value1")))
+	ensure.Nil(t, db.Flush(fo))
+
+	opts := NewCompactRangeOptions()
+	defer opts.Release()
+	opts.SetChangeLevel(true)
+	opts.SetTargetLevel(3)
+	opts.SetBottommostLevelCompaction(BottommostLevelCompactionForce)
+	ensure.Nil(t, db.CompactRangeWithOptions(opts, Range{nil, nil}))
+
+	files := db.GetLiveFilesMetaData()
+	ensure.DeepEqual(t, len(files), 1)
+	ensure.DeepEqual(t, files[0].Level, 3)
+}
+
+func TestDBCompactFiles(t *testing.T) {
+	db := newTestDB(t, "TestDBCompactFiles", nil)
+	defer db.Release()
+
+	wo := NewWriteOptions()
+	defer wo.Release()
+	fo := NewFlushOptions()
+	defer fo.Release()
+	ensure.Nil(t, db.Put(wo, []byte("key1"), []byte("value1")))
+	ensure.Nil(t, db.Flush(fo))
+	ensure.Nil(t, db.Put(wo, []byte("key2"), []byte("value2")))
+	ensure.Nil(t, db.Flush(fo))
+
+	var names []string
+	for _, f := range db.GetLiveFilesMetaData() {
+		ensure.DeepEqual(t, f.Level, 0)
+		names = append(names, f.Name)
+	}
+	ensure.DeepEqual(t, len(names), 2)
+	ensure.Nil(t, db.CompactFiles(names, 1))
+
+	files := db.GetLiveFilesMetaData()
+	ensure.DeepEqual(t, len(files), 1)
+	ensure.DeepEqual(t, files[0].Level, 1)
+
+	ensure.NotNil(t, db.CompactFiles([]string{"/999999.sst"}, 1))
+}
+
+func TestDBGetColumnFamilyMetaData(t *testing.T) {
+	db := newTestDB(t, "TestDBGetColumnFamilyMetaData", nil)
+	defer db.Release()
+
+	wo := NewWriteOptions()
+	defer wo.Release()
+	ensure.Nil(t, db.Put(wo, []byte("key1"), []byte("value1")))
+	ensure.Nil(t, db.Put(wo, []byte("key2"), []byte("value2")))
+	ensure.Nil(t, db.Delete(wo, []byte("key3")))
+	fo := NewFlushOptions()
+	defer fo.Release()
+	ensure.Nil(t, db.Flush(fo))
+
+	files := db.GetLiveFilesMetaData()
+	ensure.DeepEqual(t, len(files), 1)
+	ensure.DeepEqual(t, files[0].CFName, "default")
+	ensure.DeepEqual(t, files[0].NumEntries, uint64(3))
+	ensure.DeepEqual(t, files[0].NumDeletions, uint64(1))
+	ensure.DeepEqual(t, files[0].SmallestSeqno, uint64(1))
+	ensure.DeepEqual(t, files[0].LargestSeqno, uint64(3))
+	ensure.False(t, files[0].BeingCompacted)
+
+	meta := db.GetColumnFamilyMetaData(nil)
+	ensure.DeepEqual(t, meta.Name, "default")
+	ensure.DeepEqual(t, meta.FileCount, 1)
+	ensure.DeepEqual(t, meta.Size, uint64(files[0].Size))
+	ensure.True(t, len(meta.Levels) > 1)
+	ensure.DeepEqual(t, meta.Levels[0].Size, uint64(files[0].Size))
+	ensure.DeepEqual(t, len(meta.Levels[0].Files), 1)
+	ensure.DeepEqual(t, meta.Levels[0].Files[0].Name, files[0].Name)
+	ensure.DeepEqual(t, meta.Levels[0].Files[0].SmallestKey, []byte("key1"))
+	ensure.DeepEqual(t, meta.Levels[0].Files[0].LargestKey, []byte("key3"))
+	ensure.DeepEqual(t, len(meta.Levels[1].Files), 0)
+}
+
+func TestDBGetPinned(t *testing.T) {
+	db := newTestDB(t, "TestDBGetPinned", nil)
+	defer db.Release()
+
+	var (
+		givenKey = []byte("hello")
+		givenVal = []byte("world")
+		wo       = NewWriteOptions()
+		ro       = NewReadOptions()
+	)
+	defer wo.Release()
+	defer ro.Release()
+	ensure.Nil(t, db.Put(wo, givenKey, givenVal))
+
+	// from the memtable
+	h1, err := db.GetPinned(ro, givenKey)
+	ensure.Nil(t, err)
+	ensure.True(t, h1.Exists())
+	ensure.DeepEqual(t, h1.Data(), givenVal)
+	h1.Release()
+
+	// from the block cache
+	fo := NewFlushOptions()
+	defer fo.Release()
+	ensure.Nil(t, db.Flush(fo))
+	h2, err := db.GetPinned(ro, givenKey)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, h2.Data(), givenVal)
+	h2.Release()
+	h2.Release()
+
+	h3, err := db.GetPinned(ro, []byte("missing"))
+	ensure.Nil(t, err)
+	ensure.False(t, h3.Exists())
+	ensure.True(t, h3.Data() == nil)
+	h3.Release()
+}
+
+func TestDBGetBytes(t *testing.T) {
+	db := newTestDB(t, "TestDBGetBytes", nil)
+	defer db.Release()
+
+	var (
+		wo = NewWriteOptions()
+		ro = NewReadOptions()
+	)
+	defer wo.Release()
+	defer ro.Release()
+	ensure.Nil(t, db.Put(wo, []byte("key1"), []byte("value1")))
+	ensure.Nil(t, db.Put(wo, []byte("empty"), nil))
+
+	v, err := db.GetBytes(ro, []byte("key1"))
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v, []byte("value1"))
+
+	v, err = db.GetBytes(ro, []byte("empty"))
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v, []byte{})
+
+	v, err = db.GetBytes(ro, []byte("missing"))
+	ensure.Nil(t, err)
+	ensure.True(t, v == nil)
+
+	buf := []byte("prefix:")
+	buf, found, err := db.GetInto(ro, []byte("key1"), buf)
+	ensure.Nil(t, err)
+	ensure.True(t, found)
+	ensure.DeepEqual(t, buf, []byte("prefix:value1"))
+
+	buf, found, err = db.GetInto(ro, []byte("missing"), buf[:0])
+	ensure.Nil(t, err)
+	ensure.False(t, found)
+	ensure.DeepEqual(t, len(buf), 0)
+}
+
+func TestDBKeyMayExist(t *testing.T) {
+	policy := &mockFilterPolicy{
+		createFilter: func(keys [][]byte) []byte {
+			return bytes.Join(keys, []byte{0})
+		},
+		keyMayMatch: func(key, filter []byte) bool {
+			for _, k := range bytes.Split(filter, []byte{0}) {
+				if bytes.Equal(k, key) {
+					return true
+				}
+			}
+			return false
+		},
+	}
+	db := newTestDB(t, "TestDBKeyMayExist", func(opts *Options) {
+		blockOpts := NewBlockBasedTableOptions()
+		blockOpts.SetFilterPolicy(policy)
+		opts.SetBlockBasedTableFactory(blockOpts)
+	})
+	defer db.Release()
+
+	var (
+		wo = NewWriteOptions()
+		ro = NewReadOptions()
+	)
+	defer wo.Release()
+	defer ro.Release()
+	ensure.Nil(t, db.Put(wo, []byte("key1"), []byte("value1")))
+	ensure.Nil(t, db.Put(wo, []byte("key3"), []byte("value3")))
+	fo := NewFlushOptions()
+	defer fo.Release()
+	ensure.Nil(t, db.Flush(fo))
+
+	ensure.True(t, db.KeyMayExist(ro, []byte("key1")))
+	ensure.False(t, db.KeyMayExist(ro, []byte("key2")))
+}
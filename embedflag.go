@@ -2,7 +2,7 @@
 
 package gorocksdb
 
-// #cgo CXXFLAGS: -std=c++11
+// #cgo CXXFLAGS: -std=c++17
 // #cgo CPPFLAGS: -I${SRCDIR}/../../cockroachdb/c-lz4/internal/lib
 // #cgo CPPFLAGS: -I${SRCDIR}/../../daaku/c-rocksdb/internal/include
 // #cgo CPPFLAGS: -I${SRCDIR}/../../cockroachdb/c-snappy/internal
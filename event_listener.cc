@@ -0,0 +1,121 @@
+#include <string>
+#include <vector>
+
+#include "rocksdb/listener.h"
+#include "gorocksdb_internal.h"
+
+extern "C" {
+// Implemented in event_listener.go.
+void gorocksdb_eventlistener_on_flush_completed(uintptr_t idx, gorocksdb_flushjobinfo_t* info);
+void gorocksdb_eventlistener_on_compaction_completed(uintptr_t idx, gorocksdb_compactionjobinfo_t* info);
+void gorocksdb_eventlistener_on_stall_conditions_changed(uintptr_t idx, gorocksdb_writestallinfo_t* info);
+void gorocksdb_eventlistener_on_background_error(uintptr_t idx, int reason, const char* status);
+}
+
+using rocksdb::BackgroundErrorReason;
+using rocksdb::CompactionJobInfo;
+using rocksdb::DB;
+using rocksdb::EventListener;
+using rocksdb::FlushJobInfo;
+using rocksdb::Status;
+using rocksdb::WriteStallCondition;
+using rocksdb::WriteStallInfo;
+
+// Values of WriteStallCondition in event_listener.go.
+static int gorocksdb_write_stall_condition(WriteStallCondition c) {
+    switch (c) {
+    case WriteStallCondition::kDelayed:
+        return 1;
+    case WriteStallCondition::kStopped:
+        return 2;
+    default:
+        return 0;
+    }
+}
+
+// Values of BackgroundErrorReason in event_listener.go.
+static int gorocksdb_background_error_reason(BackgroundErrorReason r) {
+    switch (r) {
+    case BackgroundErrorReason::kFlush:
+        return 0;
+    case BackgroundErrorReason::kCompaction:
+        return 1;
+    case BackgroundErrorReason::kWriteCallback:
+        return 2;
+    case BackgroundErrorReason::kMemTable:
+        return 3;
+    default:
+        return 4;
+    }
+}
+
+static std::vector<const char*> gorocksdb_c_strings(const std::vector<std::string>& v) {
+    std::vector<const char*> c(v.size());
+    for (size_t i = 0; i < v.size(); i++) {
+        c[i] = v[i].c_str();
+    }
+    return c;
+}
+
+class GoEventListener : public EventListener {
+  public:
+    explicit GoEventListener(uintptr_t idx) : idx_(idx) {}
+
+    void OnFlushCompleted(DB* db, const FlushJobInfo& fi) override {
+        gorocksdb_flushjobinfo_t info;
+        info.cf_id = fi.cf_id;
+        info.cf_name = fi.cf_name.c_str();
+        info.job_id = fi.job_id;
+        info.file_path = fi.file_path.c_str();
+        info.smallest_seqno = fi.smallest_seqno;
+        info.largest_seqno = fi.largest_seqno;
+        info.num_entries = fi.table_properties.num_entries;
+        info.bytes = fi.table_properties.data_size + fi.table_properties.index_size + fi.table_properties.filter_size;
+        info.triggered_writes_slowdown = fi.triggered_writes_slowdown;
+        info.triggered_writes_stop = fi.triggered_writes_stop;
+        gorocksdb_eventlistener_on_flush_completed(idx_, &info);
+    }
+
+    void OnCompactionCompleted(DB* db, const CompactionJobInfo& ci) override {
+        std::string status = ci.status.ToString();
+        std::vector<const char*> inputs = gorocksdb_c_strings(ci.input_files);
+        std::vector<const char*> outputs = gorocksdb_c_strings(ci.output_files);
+        gorocksdb_compactionjobinfo_t info;
+        info.cf_id = ci.cf_id;
+        info.cf_name = ci.cf_name.c_str();
+        info.job_id = ci.job_id;
+        info.status = ci.status.ok() ? NULL : status.c_str();
+        info.base_input_level = ci.base_input_level;
+        info.output_level = ci.output_level;
+        info.input_files = inputs.data();
+        info.num_input_files = inputs.size();
+        info.output_files = outputs.data();
+        info.num_output_files = outputs.size();
+        info.input_records = ci.stats.num_input_records;
+        info.output_records = ci.stats.num_output_records;
+        info.input_bytes = ci.stats.total_input_bytes;
+        info.output_bytes = ci.stats.total_output_bytes;
+        info.elapsed_micros = ci.stats.elapsed_micros;
+        gorocksdb_eventlistener_on_compaction_completed(idx_, &info);
+    }
+
+    void OnStallConditionsChanged(const WriteStallInfo& wi) override {
+        gorocksdb_writestallinfo_t info;
+        info.cf_name = wi.cf_name.c_str();
+        info.cur = gorocksdb_write_stall_condition(wi.condition.cur);
+        info.prev = gorocksdb_write_stall_condition(wi.condition.prev);
+        gorocksdb_eventlistener_on_stall_conditions_changed(idx_, &info);
+    }
+
+    void OnBackgroundError(BackgroundErrorReason reason, Status* bg_error) override {
+        std::string status = bg_error->ToString();
+        gorocksdb_eventlistener_on_background_error(idx_, gorocksdb_background_error_reason(reason), status.c_str());
+    }
+
+  private:
+    uintptr_t idx_;
+};
+
+void gorocksdb_options_add_eventlistener(rocksdb_options_t* opts, uintptr_t idx) {
+    opts->rep.listeners.emplace_back(new GoEventListener(idx));
+}
@@ -0,0 +1,204 @@
+package gorocksdb
+
+// #include "gorocksdb.h"
+import "C"
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// An EventListener is notified of flushes, compactions, write stalls and
+// background errors happening in a database. See Options.AddEventListener.
+//
+// The methods are called from the background threads of RocksDB, possibly
+// concurrently, and block the operation they report on until they return.
+// They must not call back into the database.
+type EventListener interface {
+	// OnFlushCompleted is called when a flush finished writing a file.
+	OnFlushCompleted(info FlushJobInfo)
+
+	// OnCompactionCompleted is called when a compaction finished, whether
+	// it succeeded or not.
+	OnCompactionCompleted(info CompactionJobInfo)
+
+	// OnStallConditionsChanged is called when writes to a column family
+	// start or stop being delayed or stopped.
+	OnStallConditionsChanged(info WriteStallInfo)
+
+	// OnBackgroundError is called when a background operation failed.
+	// Further writes fail until the error is resolved.
+	OnBackgroundError(reason BackgroundErrorReason, err error)
+}
+
+// NopEventListener implements EventListener ignoring all events. It can be
+// embedded by listeners only interested in some events.
+type NopEventListener struct{}
+
+// OnFlushCompleted implements EventListener.
+func (NopEventListener) OnFlushCompleted(info FlushJobInfo) {}
+
+// OnCompactionCompleted implements EventListener.
+func (NopEventListener) OnCompactionCompleted(info CompactionJobInfo) {}
+
+// OnStallConditionsChanged implements EventListener.
+func (NopEventListener) OnStallConditionsChanged(info WriteStallInfo) {}
+
+// OnBackgroundError implements EventListener.
+func (NopEventListener) OnBackgroundError(reason BackgroundErrorReason, err error) {}
+
+// FlushJobInfo describes a completed flush.
+type FlushJobInfo struct {
+	CFID   uint32
+	CFName string
+	JobID  int
+	// FilePath is the path of the written level 0 file.
+	FilePath      string
+	SmallestSeqno uint64
+	LargestSeqno  uint64
+	NumEntries    uint64
+	// Bytes is the size of the data, index and filter blocks written.
+	Bytes uint64
+	// TriggeredWritesSlowdown and TriggeredWritesStop report whether
+	// writes were delayed or stopped while waiting for the flush.
+	TriggeredWritesSlowdown bool
+	TriggeredWritesStop     bool
+}
+
+// CompactionJobInfo describes a completed compaction.
+type CompactionJobInfo struct {
+	CFID   uint32
+	CFName string
+	JobID  int
+	// Err is the reason the compaction failed, or nil if it succeeded.
+	Err            error
+	BaseInputLevel int
+	OutputLevel    int
+	InputFiles     []string
+	OutputFiles    []string
+	InputRecords   uint64
+	OutputRecords  uint64
+	InputBytes     uint64
+	OutputBytes    uint64
+	ElapsedMicros  uint64
+}
+
+// WriteStallCondition describes whether writes are delayed or stopped.
+type WriteStallCondition int
+
+// Write stall conditions.
+const (
+	WriteStallNormal  = WriteStallCondition(0)
+	WriteStallDelayed = WriteStallCondition(1)
+	WriteStallStopped = WriteStallCondition(2)
+)
+
+// WriteStallInfo describes a change of the write stall condition of a
+// column family.
+type WriteStallInfo struct {
+	CFName string
+	Cur    WriteStallCondition
+	Prev   WriteStallCondition
+}
+
+// BackgroundErrorReason is the operation which caused a background error.
+type BackgroundErrorReason int
+
+// Background error reasons.
+const (
+	BackgroundErrorFlush         = BackgroundErrorReason(0)
+	BackgroundErrorCompaction    = BackgroundErrorReason(1)
+	BackgroundErrorWriteCallback = BackgroundErrorReason(2)
+	BackgroundErrorMemTable      = BackgroundErrorReason(3)
+	BackgroundErrorOther         = BackgroundErrorReason(4)
+)
+
+// AddEventListener registers a listener to be notified of the events of
+// databases opened with these options.
+func (o *Options) AddEventListener(l EventListener) {
+	idx := registerEventListener(l)
+	C.gorocksdb_options_add_eventlistener(o.c, C.uintptr_t(idx))
+}
+
+// Hold references to event listeners. They are read from the background
+// threads of RocksDB while other databases may register new ones.
+var (
+	eventListeners    sync.Map // map[int]EventListener
+	eventListenersLen int64
+)
+
+func registerEventListener(l EventListener) int {
+	idx := int(atomic.AddInt64(&eventListenersLen, 1) - 1)
+	eventListeners.Store(idx, l)
+	return idx
+}
+
+func getEventListener(idx C.uintptr_t) EventListener {
+	l, _ := eventListeners.Load(int(idx))
+	return l.(EventListener)
+}
+
+// goStrings copies a C array of C strings.
+func goStrings(cStrs **C.char, n C.size_t) []string {
+	if n == 0 {
+		return nil
+	}
+	strs := make([]string, int(n))
+	for i, s := range charSlice(cStrs, C.int(n)) {
+		strs[i] = C.GoString(s)
+	}
+	return strs
+}
+
+//export gorocksdb_eventlistener_on_flush_completed
+func gorocksdb_eventlistener_on_flush_completed(idx C.uintptr_t, cInfo *C.gorocksdb_flushjobinfo_t) {
+	getEventListener(idx).OnFlushCompleted(FlushJobInfo{
+		CFID:                    uint32(cInfo.cf_id),
+		CFName:                  C.GoString(cInfo.cf_name),
+		JobID:                   int(cInfo.job_id),
+		FilePath:                C.GoString(cInfo.file_path),
+		SmallestSeqno:           uint64(cInfo.smallest_seqno),
+		LargestSeqno:            uint64(cInfo.largest_seqno),
+		NumEntries:              uint64(cInfo.num_entries),
+		Bytes:                   uint64(cInfo.bytes),
+		TriggeredWritesSlowdown: cInfo.triggered_writes_slowdown != 0,
+		TriggeredWritesStop:     cInfo.triggered_writes_stop != 0,
+	})
+}
+
+//export gorocksdb_eventlistener_on_compaction_completed
+func gorocksdb_eventlistener_on_compaction_completed(idx C.uintptr_t, cInfo *C.gorocksdb_compactionjobinfo_t) {
+	var err error
+	if cInfo.status != nil {
+		err = errors.New(C.GoString(cInfo.status))
+	}
+	getEventListener(idx).OnCompactionCompleted(CompactionJobInfo{
+		CFID:           uint32(cInfo.cf_id),
+		CFName:         C.GoString(cInfo.cf_name),
+		JobID:          int(cInfo.job_id),
+		Err:            err,
+		BaseInputLevel: int(cInfo.base_input_level),
+		OutputLevel:    int(cInfo.output_level),
+		InputFiles:     goStrings(cInfo.input_files, cInfo.num_input_files),
+		OutputFiles:    goStrings(cInfo.output_files, cInfo.num_output_files),
+		InputRecords:   uint64(cInfo.input_records),
+		OutputRecords:  uint64(cInfo.output_records),
+		InputBytes:     uint64(cInfo.input_bytes),
+		OutputBytes:    uint64(cInfo.output_bytes),
+		ElapsedMicros:  uint64(cInfo.elapsed_micros),
+	})
+}
+
+//export gorocksdb_eventlistener_on_stall_conditions_changed
+func gorocksdb_eventlistener_on_stall_conditions_changed(idx C.uintptr_t, cInfo *C.gorocksdb_writestallinfo_t) {
+	getEventListener(idx).OnStallConditionsChanged(WriteStallInfo{
+		CFName: C.GoString(cInfo.cf_name),
+		Cur:    WriteStallCondition(cInfo.cur),
+		Prev:   WriteStallCondition(cInfo.prev),
+	})
+}
+
+//export gorocksdb_eventlistener_on_background_error
+func gorocksdb_eventlistener_on_background_error(idx C.uintptr_t, cReason C.int, cStatus *C.char) {
+	getEventListener(idx).OnBackgroundError(BackgroundErrorReason(cReason), errors.New(C.GoString(cStatus)))
+}
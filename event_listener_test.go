@@ -0,0 +1,72 @@
+package gorocksdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestEventListener(t *testing.T) {
+	listener := &mockEventListener{
+		flushes:     make(chan FlushJobInfo, 10),
+		compactions: make(chan CompactionJobInfo, 10),
+	}
+	db := newTestDB(t, "TestEventListener", func(opts *Options) {
+		opts.AddEventListener(listener)
+	})
+	defer db.Release()
+
+	wo := NewWriteOptions()
+	defer wo.Release()
+	ensure.Nil(t, db.Put(wo, []byte("key1"), []byte("val1")))
+	ensure.Nil(t, db.Put(wo, []byte("key2"), []byte("val2")))
+	fo := NewFlushOptions()
+	defer fo.Release()
+	ensure.Nil(t, db.Flush(fo))
+
+	select {
+	case info := <-listener.flushes:
+		ensure.DeepEqual(t, info.CFName, "default")
+		ensure.DeepEqual(t, info.NumEntries, uint64(2))
+		ensure.DeepEqual(t, info.SmallestSeqno, uint64(1))
+		ensure.DeepEqual(t, info.LargestSeqno, uint64(2))
+		ensure.True(t, info.FilePath != "")
+		ensure.True(t, info.Bytes > 0)
+	case <-time.After(10 * time.Second):
+		t.Fatal("flush not reported")
+	}
+
+	// a second file to compact with the first one
+	ensure.Nil(t, db.Put(wo, []byte("key1"), []byte("val3")))
+	ensure.Nil(t, db.Flush(fo))
+	<-listener.flushes
+	ensure.Nil(t, db.CompactRange(Range{nil, nil}))
+
+	select {
+	case info := <-listener.compactions:
+		ensure.DeepEqual(t, info.CFName, "default")
+		ensure.Nil(t, info.Err)
+		ensure.DeepEqual(t, len(info.InputFiles), 2)
+		ensure.DeepEqual(t, len(info.OutputFiles), 1)
+		ensure.DeepEqual(t, info.InputRecords, uint64(3))
+		ensure.DeepEqual(t, info.OutputRecords, uint64(2))
+		ensure.True(t, info.OutputBytes > 0)
+	case <-time.After(10 * time.Second):
+		t.Fatal("compaction not reported")
+	}
+}
+
+type mockEventListener struct {
+	NopEventListener
+	flushes     chan FlushJobInfo
+	compactions chan CompactionJobInfo
+}
+
+func (l *mockEventListener) OnFlushCompleted(info FlushJobInfo) {
+	l.flushes <- info
+}
+
+func (l *mockEventListener) OnCompactionCompleted(info CompactionJobInfo) {
+	l.compactions <- info
+}
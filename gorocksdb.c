@@ -1,3 +1,4 @@
+#include <string.h>
 #include "gorocksdb.h"
 #include "_cgo_export.h"
 
@@ -15,16 +16,6 @@ rocksdb_comparator_t* gorocksdb_comparator_create(uintptr_t idx) {
         (const char *(*)(void*))(gorocksdb_comparator_name));
 }
 
-/* CompactionFilter */
-
-rocksdb_compactionfilter_t* gorocksdb_compactionfilter_create(uintptr_t idx) {
-    return rocksdb_compactionfilter_create(
-        (void*)idx,
-        gorocksdb_destruct_handler,
-        (unsigned char (*)(void*, int, const char*, size_t, const char*, size_t, char**, size_t*, unsigned char*))(gorocksdb_compactionfilter_filter),
-        (const char *(*)(void*))(gorocksdb_compactionfilter_name));
-}
-
 /* Filter Policy */
 
 rocksdb_filterpolicy_t* gorocksdb_filterpolicy_create(uintptr_t idx) {
@@ -64,3 +55,29 @@ rocksdb_slicetransform_t* gorocksdb_slicetransform_create(uintptr_t idx) {
     	(unsigned char (*)(void*, const char*, size_t))(gorocksdb_slicetransform_in_range),
     	(const char* (*)(void*))(gorocksdb_slicetransform_name));
 }
+
+/* Iterator */
+
+size_t gorocksdb_iter_next_batch(rocksdb_iterator_t* iter, size_t max, char* buf, size_t buf_len, size_t* key_lens, size_t* val_lens, size_t* used, size_t* needed) {
+    size_t n = 0;
+    *used = 0;
+    *needed = 0;
+    while (n < max && rocksdb_iter_valid(iter)) {
+        size_t key_len, val_len;
+        const char* key = rocksdb_iter_key(iter, &key_len);
+        const char* val = rocksdb_iter_value(iter, &val_len);
+        if (*used + key_len + val_len > buf_len) {
+            // the buffer cannot hold the next entry
+            *needed = key_len + val_len;
+            break;
+        }
+        memcpy(buf + *used, key, key_len);
+        memcpy(buf + *used + key_len, val, val_len);
+        *used += key_len + val_len;
+        key_lens[n] = key_len;
+        val_lens[n] = val_len;
+        n++;
+        rocksdb_iter_next(iter);
+    }
+    return n;
+}
@@ -1,3 +1,6 @@
+#ifndef GOROCKSDB_H
+#define GOROCKSDB_H
+
 #include <stdlib.h>
 #include "rocksdb/c.h"
 
@@ -9,7 +12,15 @@ extern void gorocksdb_destruct_handler(void* state);
 
 /* CompactionFilter */
 
-extern rocksdb_compactionfilter_t* gorocksdb_compactionfilter_create(uintptr_t idx);
+typedef struct gorocksdb_compactionfilter_t gorocksdb_compactionfilter_t;
+
+extern gorocksdb_compactionfilter_t* gorocksdb_compactionfilter_create(uintptr_t idx);
+extern void gorocksdb_compactionfilter_destroy(gorocksdb_compactionfilter_t* filter);
+extern void gorocksdb_options_set_compaction_filter(rocksdb_options_t* opts, gorocksdb_compactionfilter_t* filter);
+
+/* CompactionFilterFactory */
+
+extern void gorocksdb_options_set_compaction_filter_factory(rocksdb_options_t* opts, uintptr_t idx);
 
 /* Comparator */
 
@@ -28,3 +39,133 @@ extern void gorocksdb_mergeoperator_delete_value(void* state, const char* v, siz
 /* Slice Transform */
 
 extern rocksdb_slicetransform_t* gorocksdb_slicetransform_create(uintptr_t idx);
+
+/* Iterator */
+
+extern size_t gorocksdb_iter_next_batch(rocksdb_iterator_t* iter, size_t max, char* buf, size_t buf_len, size_t* key_lens, size_t* val_lens, size_t* used, size_t* needed);
+
+/* DeleteRange */
+
+extern void gorocksdb_delete_range(rocksdb_t* db, const rocksdb_writeoptions_t* opts, const char* start_key, size_t start_key_len, const char* end_key, size_t end_key_len, char** errptr);
+
+/* Compaction */
+
+extern void gorocksdb_compact_range(rocksdb_t* db, rocksdb_column_family_handle_t* cf, rocksdb_compactoptions_t* opts, const char* start_key, size_t start_key_len, const char* limit_key, size_t limit_key_len, char** errptr);
+extern void gorocksdb_compact_files(rocksdb_t* db, rocksdb_column_family_handle_t* cf, char** file_names, size_t num_files, int output_level, char** errptr);
+
+/* Metadata */
+
+typedef struct {
+    const char* name;
+    const char* cf_name;
+    int level;
+    uint64_t size;
+    const char* smallest_key;
+    size_t smallest_key_len;
+    const char* largest_key;
+    size_t largest_key_len;
+    uint64_t smallest_seqno;
+    uint64_t largest_seqno;
+    uint64_t num_entries;
+    uint64_t num_deletions;
+    unsigned char being_compacted;
+} gorocksdb_sstfilemetadata_t;
+
+typedef struct {
+    const char* cf_name;
+    uint64_t size;
+    const gorocksdb_sstfilemetadata_t* files;
+    size_t num_files;
+    const uint64_t* level_sizes;
+    int num_levels;
+} gorocksdb_metadata_t;
+
+extern gorocksdb_metadata_t* gorocksdb_livefiles_metadata(rocksdb_t* db);
+extern gorocksdb_metadata_t* gorocksdb_column_family_metadata(rocksdb_t* db, rocksdb_column_family_handle_t* cf);
+extern void gorocksdb_metadata_destroy(gorocksdb_metadata_t* metadata);
+
+/* Properties */
+
+typedef struct {
+    const char* const* keys;
+    const size_t* key_lens;
+    const char* const* values;
+    const size_t* value_lens;
+    size_t num_entries;
+} gorocksdb_map_property_t;
+
+extern gorocksdb_map_property_t* gorocksdb_property_map(rocksdb_t* db, rocksdb_column_family_handle_t* cf, const char* propname, size_t propname_len);
+extern void gorocksdb_map_property_destroy(gorocksdb_map_property_t* property);
+
+/* Statistics */
+
+typedef struct {
+    double median;
+    double p95;
+    double p99;
+    double max;
+    uint64_t count;
+    uint64_t sum;
+} gorocksdb_histogramdata_t;
+
+extern size_t gorocksdb_statistics_num_tickers(void);
+extern const char* gorocksdb_statistics_ticker_name(size_t i);
+extern size_t gorocksdb_statistics_num_histograms(void);
+extern const char* gorocksdb_statistics_histogram_name(size_t i);
+extern unsigned char gorocksdb_options_statistics_get_tickers(rocksdb_options_t* opts, uint64_t* values);
+extern unsigned char gorocksdb_options_statistics_get_ticker(rocksdb_options_t* opts, size_t i, uint64_t* value);
+extern unsigned char gorocksdb_options_statistics_get_histograms(rocksdb_options_t* opts, gorocksdb_histogramdata_t* data);
+extern unsigned char gorocksdb_options_statistics_get_histogram(rocksdb_options_t* opts, size_t i, gorocksdb_histogramdata_t* data);
+extern void gorocksdb_options_statistics_reset(rocksdb_options_t* opts, char** errptr);
+
+/* Transaction */
+
+extern const rocksdb_snapshot_t* gorocksdb_transaction_get_snapshot(rocksdb_transaction_t* txn);
+
+/* TTL */
+
+extern rocksdb_t* gorocksdb_ttl_base_db(rocksdb_t* db);
+extern void gorocksdb_ttl_base_db_destroy(rocksdb_t* base);
+
+/* Event Listener */
+
+typedef struct {
+    uint32_t cf_id;
+    const char* cf_name;
+    int job_id;
+    const char* file_path;
+    uint64_t smallest_seqno;
+    uint64_t largest_seqno;
+    uint64_t num_entries;
+    uint64_t bytes;
+    unsigned char triggered_writes_slowdown;
+    unsigned char triggered_writes_stop;
+} gorocksdb_flushjobinfo_t;
+
+typedef struct {
+    uint32_t cf_id;
+    const char* cf_name;
+    int job_id;
+    const char* status;
+    int base_input_level;
+    int output_level;
+    const char* const* input_files;
+    size_t num_input_files;
+    const char* const* output_files;
+    size_t num_output_files;
+    uint64_t input_records;
+    uint64_t output_records;
+    uint64_t input_bytes;
+    uint64_t output_bytes;
+    uint64_t elapsed_micros;
+} gorocksdb_compactionjobinfo_t;
+
+typedef struct {
+    const char* cf_name;
+    int cur;
+    int prev;
+} gorocksdb_writestallinfo_t;
+
+extern void gorocksdb_options_add_eventlistener(rocksdb_options_t* opts, uintptr_t idx);
+
+#endif
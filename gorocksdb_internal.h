@@ -0,0 +1,34 @@
+// Definitions shared by the C++ wrappers for the parts of RocksDB the C API
+// does not expose.
+
+#ifndef GOROCKSDB_INTERNAL_H
+#define GOROCKSDB_INTERNAL_H
+
+#include <stdlib.h>
+#include <string.h>
+
+#include "rocksdb/db.h"
+#include "rocksdb/options.h"
+
+extern "C" {
+#include "gorocksdb.h"
+}
+
+// The C API does not give access to the C++ objects, these must match the
+// definitions in rocksdb/db/c.cc.
+struct rocksdb_t { rocksdb::DB* rep; };
+struct rocksdb_column_family_handle_t { rocksdb::ColumnFamilyHandle* rep; };
+struct rocksdb_options_t { rocksdb::Options rep; };
+struct rocksdb_compactoptions_t { rocksdb::CompactRangeOptions rep; };
+struct rocksdb_snapshot_t { const rocksdb::Snapshot* rep; };
+
+// Stores a failed status in errptr the way the C API does.
+static inline void gorocksdb_save_error(char** errptr, const rocksdb::Status& s) {
+    if (s.ok()) {
+        return;
+    }
+    free(*errptr);
+    *errptr = strdup(s.ToString().c_str());
+}
+
+#endif
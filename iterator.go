@@ -2,6 +2,7 @@ package gorocksdb
 
 // #include <stdlib.h>
 // #include "rocksdb/c.h"
+// #include "gorocksdb.h"
 import "C"
 import "bytes"
 
@@ -24,11 +25,24 @@ import "bytes"
 //
 type Iterator struct {
 	c *C.rocksdb_iterator_t
+
+	// expiry is set for iterators over the base database of a database
+	// opened with a TTL, whose values end with their write timestamp.
+	expiry *ttlExpiry
+
+	// buffers reused by NextBatch
+	batchArena   []byte
+	batchKeyLens []C.size_t
+	batchValLens []C.size_t
+	batchKeys    [][]byte
+	batchValues  [][]byte
 }
 
 // newNativeIterator creates a Iterator object.
 func newNativeIterator(c *C.rocksdb_iterator_t) *Iterator {
-	return &Iterator{c}
+	i := &Iterator{c: c}
+	trackHandle(i)
+	return i
 }
 
 // Valid returns false only when an Iterator has iterated past either the
@@ -39,6 +53,8 @@ func (i *Iterator) Valid() bool {
 
 // ValidForPrefix returns false only when an Iterator has iterated past the
 // first or the last key in the database or the specified prefix.
+// ReadOptions.SetIterateUpperBound and SetPrefixSameAsStart avoid reading
+// the key on every step and stop RocksDB itself at the end of the prefix.
 func (i *Iterator) ValidForPrefix(prefix []byte) bool {
 	return C.rocksdb_iter_valid(i.c) != 0 && bytes.HasPrefix(i.Key().Data(), prefix)
 }
@@ -60,33 +76,221 @@ func (i *Iterator) Value() *Slice {
 	if cVal == nil {
 		return nil
 	}
+	if i.expiry != nil && cLen >= ttlTimestampSize {
+		cLen -= ttlTimestampSize
+	}
 	return &Slice{cVal, cLen, true}
 }
 
 // Next moves the iterator to the next sequential key in the database.
 func (i *Iterator) Next() {
 	C.rocksdb_iter_next(i.c)
+	if i.expiry != nil {
+		i.skipExpired(true)
+	}
 }
 
 // Prev moves the iterator to the previous sequential key in the database.
 func (i *Iterator) Prev() {
 	C.rocksdb_iter_prev(i.c)
+	if i.expiry != nil {
+		i.skipExpired(false)
+	}
 }
 
 // SeekToFirst moves the iterator to the first key in the database.
 func (i *Iterator) SeekToFirst() {
 	C.rocksdb_iter_seek_to_first(i.c)
+	if i.expiry != nil {
+		i.skipExpired(true)
+	}
 }
 
 // SeekToLast moves the iterator to the last key in the database.
 func (i *Iterator) SeekToLast() {
 	C.rocksdb_iter_seek_to_last(i.c)
+	if i.expiry != nil {
+		i.skipExpired(false)
+	}
 }
 
 // Seek moves the iterator to the position greater than or equal to the key.
 func (i *Iterator) Seek(key []byte) {
 	cKey := byteToChar(key)
 	C.rocksdb_iter_seek(i.c, cKey, C.size_t(len(key)))
+	if i.expiry != nil {
+		i.skipExpired(true)
+	}
+}
+
+// SeekForPrev moves the iterator to the position less than or equal to the
+// key.
+func (i *Iterator) SeekForPrev(key []byte) {
+	cKey := byteToChar(key)
+	C.rocksdb_iter_seek_for_prev(i.c, cKey, C.size_t(len(key)))
+	if i.expiry != nil {
+		i.skipExpired(false)
+	}
+}
+
+// SeekToLastForPrefix moves the iterator to the last key with the prefix,
+// to iterate over the prefix in reverse order:
+//
+//	for it.SeekToLastForPrefix(prefix); it.ValidForPrefix(prefix); it.Prev() {
+//	}
+//
+// It supports comparators which order the keys sharing a prefix either like
+// bytes.Compare, as the default comparator does, or in the opposite order.
+func (i *Iterator) SeekToLastForPrefix(prefix []byte) {
+	if len(prefix) == 0 {
+		i.SeekToLast()
+		return
+	}
+	if successor := prefixSuccessor(prefix); successor != nil {
+		i.SeekForPrev(successor)
+		// the successor itself does not have the prefix
+		if i.Valid() && bytes.Equal(i.Key().Data(), successor) {
+			i.Prev()
+		}
+	} else {
+		i.SeekToLast()
+	}
+	if i.ValidForPrefix(prefix) {
+		return
+	}
+	// with a reverse order the keys with the prefix follow the prefix
+	i.SeekForPrev(prefix)
+}
+
+// prefixSuccessor returns the smallest key greater than all keys with the
+// prefix, or nil if there is none because the prefix only has 0xff bytes.
+func prefixSuccessor(prefix []byte) []byte {
+	for n := len(prefix); n > 0; n-- {
+		if prefix[n-1] != 0xff {
+			successor := make([]byte, n)
+			copy(successor, prefix)
+			successor[n-1]++
+			return successor
+		}
+	}
+	return nil
+}
+
+// NextBatch returns up to max entries starting with the current one and
+// moves the iterator past them, copying them with a single cgo call instead
+// of calling Key, Value, Next and Valid for every entry. It stops before the
+// copied keys and values exceed maxBytes, but returns at least one entry
+// while the iterator is valid:
+//
+//	for it.SeekToFirst(); it.Valid(); {
+//		keys, values := it.NextBatch(1024, 1<<20)
+//		for j := range keys {
+//			fmt.Printf("Key: %v Value: %v\n", keys[j], values[j])
+//		}
+//	}
+//
+// The returned slices share a buffer owned by the iterator and are only
+// valid until the next call to NextBatch or Release.
+func (i *Iterator) NextBatch(max int, maxBytes int) (keys, values [][]byte) {
+	if max <= 0 || !i.Valid() {
+		return nil, nil
+	}
+	if cap(i.batchKeyLens) < max {
+		i.batchKeyLens = make([]C.size_t, max)
+		i.batchValLens = make([]C.size_t, max)
+		i.batchKeys = make([][]byte, max)
+		i.batchValues = make([][]byte, max)
+	}
+	if maxBytes < 0 {
+		maxBytes = 0
+	}
+
+	var n int
+	if i.expiry != nil {
+		// expired entries must be skipped, which Next does
+		n = i.nextBatchSlow(max, maxBytes)
+	} else {
+		n = i.nextBatch(max, maxBytes)
+	}
+
+	keys, values = i.batchKeys[:n], i.batchValues[:n]
+	off := 0
+	for j := 0; j < n; j++ {
+		keyLen, valLen := int(i.batchKeyLens[j]), int(i.batchValLens[j])
+		keys[j] = i.batchArena[off : off+keyLen : off+keyLen]
+		off += keyLen
+		values[j] = i.batchArena[off : off+valLen : off+valLen]
+		off += valLen
+	}
+	return keys, values
+}
+
+// nextBatch copies entries into the batch buffers in C, growing the arena
+// whenever the next entry does not fit into it but into maxBytes.
+func (i *Iterator) nextBatch(max int, maxBytes int) int {
+	n, used := 0, 0
+	for n < max {
+		limit := len(i.batchArena)
+		if limit > maxBytes {
+			limit = maxBytes
+		}
+		var cUsed, cNeeded C.size_t
+		buf := i.batchArena[used:limit]
+		n += int(C.gorocksdb_iter_next_batch(i.c, C.size_t(max-n), byteToChar(buf), C.size_t(len(buf)),
+			&i.batchKeyLens[n], &i.batchValLens[n], &cUsed, &cNeeded))
+		used += int(cUsed)
+		if cNeeded == 0 {
+			// max entries were copied or the iterator is exhausted
+			break
+		}
+		needed := used + int(cNeeded)
+		if needed > maxBytes {
+			if n > 0 {
+				break
+			}
+			// the first entry is returned even if it exceeds maxBytes
+			maxBytes = needed
+		}
+		if len(i.batchArena) < needed {
+			i.growBatchArena(needed, maxBytes, used)
+		}
+	}
+	return n
+}
+
+// growBatchArena grows the arena to hold at least needed bytes, doubling it
+// up to maxBytes, and keeps its first used bytes.
+func (i *Iterator) growBatchArena(needed, maxBytes, used int) {
+	size := 2 * len(i.batchArena)
+	if size > maxBytes {
+		size = maxBytes
+	}
+	if size < needed {
+		size = needed
+	}
+	arena := make([]byte, size)
+	copy(arena, i.batchArena[:used])
+	i.batchArena = arena
+}
+
+// nextBatchSlow copies entries into the batch buffers with a cgo call per
+// step.
+func (i *Iterator) nextBatchSlow(max int, maxBytes int) int {
+	arena := i.batchArena[:0]
+	n := 0
+	for n < max && i.Valid() {
+		key, value := i.Key().Data(), i.Value().Data()
+		if n > 0 && len(arena)+len(key)+len(value) > maxBytes {
+			break
+		}
+		arena = append(arena, key...)
+		arena = append(arena, value...)
+		i.batchKeyLens[n], i.batchValLens[n] = C.size_t(len(key)), C.size_t(len(value))
+		n++
+		i.Next()
+	}
+	i.batchArena = arena[:cap(arena)]
+	return n
 }
 
 // Err returns nil if no errors happened during iteration, or the actual
@@ -101,4 +305,5 @@ func (i *Iterator) Err() error {
 func (i *Iterator) Release() {
 	C.rocksdb_iter_destroy(i.c)
 	i.c = nil
+	untrackHandle(i)
 }
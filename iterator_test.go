@@ -1,6 +1,9 @@
 package gorocksdb
 
 import (
+	"fmt"
+	"io/ioutil"
+	"os"
 	"testing"
 
 	"github.com/facebookgo/ensure"
@@ -29,3 +32,217 @@ func TestIterator(t *testing.T) {
 	ensure.Nil(t, iter.Err())
 	ensure.DeepEqual(t, actualKeys, givenKeys)
 }
+
+func TestIteratorBounds(t *testing.T) {
+	db := newTestDB(t, "TestIteratorBounds", nil)
+	defer db.Release()
+
+	wo := NewWriteOptions()
+	defer wo.Release()
+	for _, k := range []string{"key1", "key2", "key3", "key4"} {
+		ensure.Nil(t, db.Put(wo, []byte(k), []byte("val")))
+	}
+
+	ro := NewReadOptions()
+	defer ro.Release()
+	lower := []byte("key2")
+	upper := []byte("key4")
+	ro.SetIterateLowerBound(lower)
+	ro.SetIterateUpperBound(upper)
+	// the bounds are copied
+	lower[3], upper[3] = '0', '9'
+
+	iter := db.NewIterator(ro)
+	var forward []string
+	for iter.SeekToFirst(); iter.Valid(); iter.Next() {
+		forward = append(forward, string(iter.Key().Data()))
+	}
+	ensure.Nil(t, iter.Err())
+	ensure.DeepEqual(t, forward, []string{"key2", "key3"})
+
+	var backward []string
+	for iter.SeekToLast(); iter.Valid(); iter.Prev() {
+		backward = append(backward, string(iter.Key().Data()))
+	}
+	ensure.Nil(t, iter.Err())
+	ensure.DeepEqual(t, backward, []string{"key3", "key2"})
+	// the bounds must not change while an iterator uses them
+	iter.Release()
+
+	ro.SetIterateUpperBound(nil)
+	unbounded := db.NewIterator(ro)
+	defer unbounded.Release()
+	unbounded.SeekToLast()
+	ensure.True(t, unbounded.Valid())
+	ensure.DeepEqual(t, unbounded.Key().Data(), []byte("key4"))
+}
+
+func TestIteratorSeekForPrev(t *testing.T) {
+	db := newTestDB(t, "TestIteratorSeekForPrev", nil)
+	defer db.Release()
+
+	wo := NewWriteOptions()
+	defer wo.Release()
+	for _, k := range []string{"a1", "b1", "b2", "b3", "c1"} {
+		ensure.Nil(t, db.Put(wo, []byte(k), []byte("val")))
+	}
+
+	ro := NewReadOptions()
+	defer ro.Release()
+	iter := db.NewIterator(ro)
+	defer iter.Release()
+
+	iter.SeekForPrev([]byte("b2"))
+	ensure.True(t, iter.Valid())
+	ensure.DeepEqual(t, iter.Key().Data(), []byte("b2"))
+	iter.SeekForPrev([]byte("b25"))
+	ensure.DeepEqual(t, iter.Key().Data(), []byte("b2"))
+	iter.SeekForPrev([]byte("z"))
+	ensure.DeepEqual(t, iter.Key().Data(), []byte("c1"))
+	iter.SeekForPrev([]byte("a"))
+	ensure.False(t, iter.Valid())
+
+	ensure.DeepEqual(t, collectReversePrefix(iter, []byte("b")), []string{"b3", "b2", "b1"})
+	ensure.DeepEqual(t, collectReversePrefix(iter, []byte("c")), []string{"c1"})
+	ensure.DeepEqual(t, collectReversePrefix(iter, []byte("d")), []string(nil))
+	ensure.Nil(t, iter.Err())
+}
+
+func TestIteratorSeekToLastForPrefixSuccessorKey(t *testing.T) {
+	db := newTestDB(t, "TestIteratorSeekToLastForPrefixSuccessorKey", nil)
+	defer db.Release()
+
+	// "c" is the successor of the prefix "b"
+	wo := NewWriteOptions()
+	defer wo.Release()
+	for _, k := range []string{"a", "b1", "b2", "c"} {
+		ensure.Nil(t, db.Put(wo, []byte(k), []byte("val")))
+	}
+
+	ro := NewReadOptions()
+	defer ro.Release()
+	iter := db.NewIterator(ro)
+	defer iter.Release()
+	ensure.DeepEqual(t, collectReversePrefix(iter, []byte("b")), []string{"b2", "b1"})
+	ensure.Nil(t, iter.Err())
+}
+
+func TestPrefixSuccessor(t *testing.T) {
+	ensure.DeepEqual(t, prefixSuccessor([]byte("ab")), []byte("ac"))
+	ensure.DeepEqual(t, prefixSuccessor([]byte{'a', 0xff, 0xff}), []byte("b"))
+	ensure.True(t, prefixSuccessor([]byte{0xff}) == nil)
+	ensure.True(t, prefixSuccessor(nil) == nil)
+}
+
+func collectReversePrefix(iter *Iterator, prefix []byte) []string {
+	var keys []string
+	for iter.SeekToLastForPrefix(prefix); iter.ValidForPrefix(prefix); iter.Prev() {
+		keys = append(keys, string(iter.Key().Data()))
+	}
+	return keys
+}
+
+func TestIteratorNextBatch(t *testing.T) {
+	db := newTestDB(t, "TestIteratorNextBatch", nil)
+	defer db.Release()
+
+	wo := NewWriteOptions()
+	defer wo.Release()
+	for _, k := range []string{"key1", "key2", "key3", "key4", "key5"} {
+		ensure.Nil(t, db.Put(wo, []byte(k), []byte("val"+k[3:])))
+	}
+
+	ro := NewReadOptions()
+	defer ro.Release()
+	iter := db.NewIterator(ro)
+	defer iter.Release()
+
+	iter.SeekToFirst()
+	keys, values := iter.NextBatch(2, 1024)
+	ensure.DeepEqual(t, keys, [][]byte{[]byte("key1"), []byte("key2")})
+	ensure.DeepEqual(t, values, [][]byte{[]byte("val1"), []byte("val2")})
+	ensure.DeepEqual(t, iter.Key().Data(), []byte("key3"))
+	// the buffer grows with the entries instead of to the byte limit
+	ensure.True(t, len(iter.batchArena) < 1024)
+
+	// the byte limit fits two entries of 8 bytes
+	keys, _ = iter.NextBatch(10, 20)
+	ensure.DeepEqual(t, keys, [][]byte{[]byte("key3"), []byte("key4")})
+
+	// an entry larger than the byte limit is returned alone
+	keys, values = iter.NextBatch(10, 1)
+	ensure.DeepEqual(t, keys, [][]byte{[]byte("key5")})
+	ensure.DeepEqual(t, values, [][]byte{[]byte("val5")})
+	ensure.False(t, iter.Valid())
+
+	keys, values = iter.NextBatch(10, 1024)
+	ensure.True(t, keys == nil && values == nil)
+	ensure.Nil(t, iter.Err())
+}
+
+const benchmarkIteratorKeys = 10000
+
+func newBenchmarkIteratorDB(b *testing.B) *DB {
+	dir, err := ioutil.TempDir("", "gorocksdb-BenchmarkIterator")
+	ensure.Nil(b, err)
+	// the cleanups run after the benchmark released the database
+	b.Cleanup(func() { os.RemoveAll(dir) })
+	opts := NewOptions()
+	b.Cleanup(opts.Release)
+	opts.SetCreateIfMissing(true)
+	db, err := OpenDB(opts, dir)
+	ensure.Nil(b, err)
+
+	wo := NewWriteOptions()
+	defer wo.Release()
+	batch := NewWriteBatch()
+	defer batch.Release()
+	for i := 0; i < benchmarkIteratorKeys; i++ {
+		batch.Put([]byte(fmt.Sprintf("key%08d", i)), []byte(fmt.Sprintf("value%08d", i)))
+	}
+	ensure.Nil(b, db.Write(wo, batch))
+	return db
+}
+
+func BenchmarkIteratorPerCall(b *testing.B) {
+	db := newBenchmarkIteratorDB(b)
+	defer db.Release()
+	ro := NewReadOptions()
+	defer ro.Release()
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		iter := db.NewIterator(ro)
+		size := 0
+		for iter.SeekToFirst(); iter.Valid(); iter.Next() {
+			size += len(iter.Key().Data()) + len(iter.Value().Data())
+		}
+		iter.Release()
+		if size == 0 {
+			b.Fatal("no data")
+		}
+	}
+}
+
+func BenchmarkIteratorNextBatch(b *testing.B) {
+	db := newBenchmarkIteratorDB(b)
+	defer db.Release()
+	ro := NewReadOptions()
+	defer ro.Release()
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		iter := db.NewIterator(ro)
+		size := 0
+		for iter.SeekToFirst(); iter.Valid(); {
+			keys, values := iter.NextBatch(1024, 1<<20)
+			for j := range keys {
+				size += len(keys[j]) + len(values[j])
+			}
+		}
+		iter.Release()
+		if size == 0 {
+			b.Fatal("no data")
+		}
+	}
+}
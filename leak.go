@@ -0,0 +1,183 @@
+package gorocksdb
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// LeakCheckEnv is the environment variable enabling leak detection of
+// handles which are garbage collected without having been released. It
+// is read once at startup:
+//
+//	GOROCKSDB_LEAK_CHECK=report  print the allocation stack of leaked handles to stderr
+//	GOROCKSDB_LEAK_CHECK=panic   panic with the allocation stack of leaked handles
+//	GOROCKSDB_LEAK_CHECK=off     disable leak detection
+//
+// Building with the gorocksdb_leakcheck tag enables reporting unless the
+// variable says otherwise. Leak detection records a stack trace for every
+// handle, so it is meant for tests and debugging.
+//
+// The checked handles are DB, TransactionDB, OptimisticTransactionDB,
+// Transaction, CF, Options, ReadOptions, Iterator, Snapshot, WriteBatch,
+// Slice and PinnableSliceHandle.
+const LeakCheckEnv = "GOROCKSDB_LEAK_CHECK"
+
+// leakCheckMode is how leaked handles are handled.
+type leakCheckMode int
+
+const (
+	leakCheckOff = leakCheckMode(iota)
+	leakCheckReport
+	leakCheckPanic
+)
+
+// leakCheck is the current leakCheckMode. It is accessed atomically as
+// finalizers read it concurrently.
+var leakCheck = int32(parseLeakCheckMode(os.Getenv(LeakCheckEnv), leakCheckTagEnabled))
+
+func currentLeakCheckMode() leakCheckMode {
+	return leakCheckMode(atomic.LoadInt32(&leakCheck))
+}
+
+// setLeakCheckMode changes the leakCheckMode and returns the previous one.
+func setLeakCheckMode(mode leakCheckMode) leakCheckMode {
+	return leakCheckMode(atomic.SwapInt32(&leakCheck, int32(mode)))
+}
+
+func parseLeakCheckMode(value string, tagEnabled bool) leakCheckMode {
+	switch strings.ToLower(value) {
+	case "report", "1", "true":
+		return leakCheckReport
+	case "panic":
+		return leakCheckPanic
+	case "off", "0", "false":
+		return leakCheckOff
+	}
+	if tagEnabled {
+		return leakCheckReport
+	}
+	return leakCheckOff
+}
+
+// LiveHandle describes a handle which was not released yet.
+type LiveHandle struct {
+	// Type is the type of the handle, such as "Iterator".
+	Type string
+	// Stack is the stack trace of the allocation of the handle.
+	Stack string
+}
+
+// String returns the type and allocation stack of the handle.
+func (h LiveHandle) String() string {
+	return h.Type + " allocated at:\n" + h.Stack
+}
+
+type trackedHandle struct {
+	id  uint64
+	typ string
+	pcs []uintptr
+}
+
+var (
+	trackedMu      sync.Mutex
+	trackedNextID  uint64
+	trackedHandles = make(map[uintptr]*trackedHandle)
+)
+
+// LiveHandles returns the handles which were allocated but not released
+// yet, in allocation order. It is empty unless leak detection is enabled,
+// see LeakCheckEnv. Tests can use it to verify they release everything:
+//
+//	if live := gorocksdb.LiveHandles(); len(live) > 0 {
+//		t.Fatalf("leaked handles: %v", live)
+//	}
+func LiveHandles() []LiveHandle {
+	trackedMu.Lock()
+	handles := make([]*trackedHandle, 0, len(trackedHandles))
+	for _, h := range trackedHandles {
+		handles = append(handles, h)
+	}
+	trackedMu.Unlock()
+
+	sort.Slice(handles, func(i, j int) bool { return handles[i].id < handles[j].id })
+	live := make([]LiveHandle, len(handles))
+	for i, h := range handles {
+		live[i] = LiveHandle{Type: h.typ, Stack: formatStack(h.pcs)}
+	}
+	return live
+}
+
+// trackHandle records the allocation of a handle, which must be a pointer,
+// if leak detection is enabled.
+func trackHandle(handle interface{}) {
+	if currentLeakCheckMode() == leakCheckOff {
+		return
+	}
+	pcs := make([]uintptr, 32)
+	pcs = pcs[:runtime.Callers(3, pcs)]
+	v := reflect.ValueOf(handle)
+
+	trackedMu.Lock()
+	trackedNextID++
+	trackedHandles[v.Pointer()] = &trackedHandle{
+		id:  trackedNextID,
+		typ: v.Type().Elem().Name(),
+		pcs: pcs,
+	}
+	trackedMu.Unlock()
+
+	runtime.SetFinalizer(handle, finalizeHandle)
+}
+
+// untrackHandle records the release of a handle.
+func untrackHandle(handle interface{}) {
+	if currentLeakCheckMode() == leakCheckOff {
+		return
+	}
+	p := reflect.ValueOf(handle).Pointer()
+	trackedMu.Lock()
+	_, ok := trackedHandles[p]
+	delete(trackedHandles, p)
+	trackedMu.Unlock()
+	if ok {
+		runtime.SetFinalizer(handle, nil)
+	}
+}
+
+// finalizeHandle reports a handle which is garbage collected without having
+// been released.
+func finalizeHandle(handle interface{}) {
+	p := reflect.ValueOf(handle).Pointer()
+	trackedMu.Lock()
+	h, ok := trackedHandles[p]
+	delete(trackedHandles, p)
+	trackedMu.Unlock()
+	if !ok {
+		return
+	}
+
+	msg := fmt.Sprintf("gorocksdb: %s garbage collected without Release", LiveHandle{Type: h.typ, Stack: formatStack(h.pcs)})
+	if currentLeakCheckMode() == leakCheckPanic {
+		panic(msg)
+	}
+	fmt.Fprintln(os.Stderr, msg)
+}
+
+func formatStack(pcs []uintptr) string {
+	var b strings.Builder
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&b, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return b.String()
+}
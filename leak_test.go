@@ -0,0 +1,76 @@
+package gorocksdb
+
+import (
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestParseLeakCheckMode(t *testing.T) {
+	ensure.DeepEqual(t, parseLeakCheckMode("", false), leakCheckOff)
+	ensure.DeepEqual(t, parseLeakCheckMode("", true), leakCheckReport)
+	ensure.DeepEqual(t, parseLeakCheckMode("report", false), leakCheckReport)
+	ensure.DeepEqual(t, parseLeakCheckMode("PANIC", false), leakCheckPanic)
+	ensure.DeepEqual(t, parseLeakCheckMode("off", true), leakCheckOff)
+}
+
+func TestLiveHandles(t *testing.T) {
+	defer setLeakCheckMode(setLeakCheckMode(leakCheckReport))
+	// handles of other tests may still be live, so only the ones allocated
+	// by this test are checked
+	start := lastHandleID()
+
+	ro := NewReadOptions()
+	batch := NewWriteBatch()
+	ensure.DeepEqual(t, liveHandleTypesSince(start), []string{"ReadOptions", "WriteBatch"})
+	var stacks []string
+	for _, h := range LiveHandles() {
+		stacks = append(stacks, h.Stack)
+	}
+	ensure.True(t, strings.Contains(strings.Join(stacks, "\n"), "TestLiveHandles"))
+
+	ro.Release()
+	ensure.DeepEqual(t, liveHandleTypesSince(start), []string{"WriteBatch"})
+	batch.Release()
+	ensure.DeepEqual(t, liveHandleTypesSince(start), []string(nil))
+
+	// a handle garbage collected without Release is reported and forgotten
+	NewWriteBatch()
+	ensure.DeepEqual(t, liveHandleTypesSince(start), []string{"WriteBatch"})
+	for i := 0; i < 100 && len(liveHandleTypesSince(start)) > 0; i++ {
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+	}
+	ensure.DeepEqual(t, liveHandleTypesSince(start), []string(nil))
+}
+
+// lastHandleID returns the id of the last tracked handle.
+func lastHandleID() uint64 {
+	trackedMu.Lock()
+	defer trackedMu.Unlock()
+	return trackedNextID
+}
+
+// liveHandleTypesSince returns the types of the live handles tracked after
+// the handle with the given id, in allocation order.
+func liveHandleTypesSince(id uint64) []string {
+	trackedMu.Lock()
+	var handles []*trackedHandle
+	for _, h := range trackedHandles {
+		if h.id > id {
+			handles = append(handles, h)
+		}
+	}
+	trackedMu.Unlock()
+
+	sort.Slice(handles, func(i, j int) bool { return handles[i].id < handles[j].id })
+	var types []string
+	for _, h := range handles {
+		types = append(types, h.typ)
+	}
+	return types
+}
@@ -0,0 +1,6 @@
+//go:build gorocksdb_leakcheck
+// +build gorocksdb_leakcheck
+
+package gorocksdb
+
+const leakCheckTagEnabled = true
@@ -31,7 +31,7 @@ func TestMergeOperator(t *testing.T) {
 	ensure.Nil(t, db.Merge(wo, givenKey, givenVal2))
     
     // trigger a compaction to ensure that a merge is performed
-	db.CompactRange(Range{nil, nil})
+	ensure.Nil(t, db.CompactRange(Range{nil, nil}))
 
 	ro := NewReadOptions()
 	v1, err := db.Get(ro, givenKey)
@@ -0,0 +1,76 @@
+#include <string>
+#include <vector>
+
+#include "rocksdb/metadata.h"
+#include "gorocksdb_internal.h"
+
+using rocksdb::ColumnFamilyMetaData;
+using rocksdb::LevelMetaData;
+using rocksdb::LiveFileMetaData;
+using rocksdb::SstFileMetaData;
+
+// Owns the metadata the C view points into.
+struct gorocksdb_metadata_holder_t : public gorocksdb_metadata_t {
+    std::vector<LiveFileMetaData> live_files;
+    ColumnFamilyMetaData cf;
+    std::vector<gorocksdb_sstfilemetadata_t> file_views;
+    std::vector<uint64_t> sizes_by_level;
+
+    void add_file(const SstFileMetaData& f, const std::string& name, int level) {
+        gorocksdb_sstfilemetadata_t v;
+        v.name = f.name.c_str();
+        v.cf_name = name.c_str();
+        v.level = level;
+        v.size = f.size;
+        v.smallest_key = f.smallestkey.data();
+        v.smallest_key_len = f.smallestkey.size();
+        v.largest_key = f.largestkey.data();
+        v.largest_key_len = f.largestkey.size();
+        v.smallest_seqno = f.smallest_seqno;
+        v.largest_seqno = f.largest_seqno;
+        v.num_entries = f.num_entries;
+        v.num_deletions = f.num_deletions;
+        v.being_compacted = f.being_compacted;
+        file_views.push_back(v);
+    }
+
+    // Points the C view at the collected data, which must not change
+    // afterwards.
+    gorocksdb_metadata_t* publish() {
+        files = file_views.data();
+        num_files = file_views.size();
+        level_sizes = sizes_by_level.data();
+        num_levels = static_cast<int>(sizes_by_level.size());
+        return this;
+    }
+};
+
+gorocksdb_metadata_t* gorocksdb_livefiles_metadata(rocksdb_t* db) {
+    gorocksdb_metadata_holder_t* m = new gorocksdb_metadata_holder_t();
+    db->rep->GetLiveFilesMetaData(&m->live_files);
+    m->cf_name = NULL;
+    m->size = 0;
+    for (const LiveFileMetaData& f : m->live_files) {
+        m->add_file(f, f.column_family_name, f.level);
+        m->size += f.size;
+    }
+    return m->publish();
+}
+
+gorocksdb_metadata_t* gorocksdb_column_family_metadata(rocksdb_t* db, rocksdb_column_family_handle_t* cf) {
+    gorocksdb_metadata_holder_t* m = new gorocksdb_metadata_holder_t();
+    db->rep->GetColumnFamilyMetaData(cf != NULL ? cf->rep : db->rep->DefaultColumnFamily(), &m->cf);
+    m->cf_name = m->cf.name.c_str();
+    m->size = m->cf.size;
+    for (const LevelMetaData& level : m->cf.levels) {
+        m->sizes_by_level.push_back(level.size);
+        for (const SstFileMetaData& f : level.files) {
+            m->add_file(f, m->cf.name, level.level);
+        }
+    }
+    return m->publish();
+}
+
+void gorocksdb_metadata_destroy(gorocksdb_metadata_t* metadata) {
+    delete static_cast<gorocksdb_metadata_holder_t*>(metadata);
+}
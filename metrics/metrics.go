@@ -0,0 +1,264 @@
+// Package metrics exports the internals of a gorocksdb database in the
+// Prometheus text exposition format.
+//
+// For example:
+//
+//	h, err := metrics.NewHandler(db, []string{"default", "users"}, cfs)
+//	if err != nil {
+//		return err
+//	}
+//	h.SetStatistics(opts.Statistics())
+//	http.Handle("/metrics", h)
+package metrics
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/daaku/gorocksdb"
+)
+
+// ContentType is the content type of the Prometheus text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// cfGauges are the numeric properties exported per column family.
+var cfGauges = []struct {
+	name     string
+	help     string
+	property string
+}{
+	{"rocksdb_memtable_active_bytes", "Approximate size of the active memtable.", gorocksdb.PropertyCurSizeActiveMemTable},
+	{"rocksdb_memtable_bytes", "Approximate size of the active and unflushed immutable memtables.", gorocksdb.PropertyCurSizeAllMemTables},
+	{"rocksdb_memtable_immutable", "Number of unflushed immutable memtables.", gorocksdb.PropertyNumImmutableMemTable},
+	{"rocksdb_memtable_entries", "Number of entries in the active memtable.", gorocksdb.PropertyNumEntriesActiveMemTable},
+	{"rocksdb_estimate_num_keys", "Estimated number of keys.", gorocksdb.PropertyEstimateNumKeys},
+	{"rocksdb_estimate_pending_compaction_bytes", "Estimated bytes compaction needs to rewrite.", gorocksdb.PropertyEstimatePendingCompactionBytes},
+	{"rocksdb_live_sst_files_bytes", "Total size of the SST files of the current version.", gorocksdb.PropertyLiveSSTFilesSize},
+	{"rocksdb_compaction_pending", "Whether at least one compaction is pending.", gorocksdb.PropertyCompactionPending},
+}
+
+// dbGauges are the numeric properties exported once per database.
+var dbGauges = []struct {
+	name     string
+	help     string
+	property string
+}{
+	{"rocksdb_block_cache_capacity_bytes", "Capacity of the block cache.", gorocksdb.PropertyBlockCacheCapacity},
+	{"rocksdb_block_cache_usage_bytes", "Memory used by entries in the block cache.", gorocksdb.PropertyBlockCacheUsage},
+	{"rocksdb_block_cache_pinned_usage_bytes", "Memory used by pinned entries in the block cache.", gorocksdb.PropertyBlockCachePinnedUsage},
+	{"rocksdb_running_compactions", "Number of currently running compactions.", gorocksdb.PropertyNumRunningCompactions},
+	{"rocksdb_running_flushes", "Number of currently running flushes.", gorocksdb.PropertyNumRunningFlushes},
+	{"rocksdb_background_errors", "Accumulated number of background errors.", gorocksdb.PropertyBackgroundErrors},
+	{"rocksdb_snapshots", "Number of unreleased snapshots.", gorocksdb.PropertyNumSnapshots},
+}
+
+// Handler is a http.Handler serving the metrics of a database.
+type Handler struct {
+	db      *gorocksdb.DB
+	cfNames []string
+	cfs     []*gorocksdb.CF
+	stats   *gorocksdb.Statistics
+}
+
+// NewHandler creates a Handler object serving the metrics of db. The
+// column families are labeled with cfNames, an error is returned if it
+// does not have the same length as cfs. If cfs is empty, the default column family is exported
+// labeled "default".
+func NewHandler(db *gorocksdb.DB, cfNames []string, cfs []*gorocksdb.CF) (*Handler, error) {
+	if len(cfNames) != len(cfs) {
+		return nil, errors.New("must provide the same number of column family names and column families")
+	}
+	return &Handler{db: db, cfNames: cfNames, cfs: cfs}, nil
+}
+
+// SetStatistics sets the statistics to export tickers and histograms from,
+// usually the Statistics of the Options the database was opened with.
+func (h *Handler) SetStatistics(stats *gorocksdb.Statistics) {
+	h.stats = stats
+}
+
+// ServeHTTP writes the current metrics in the Prometheus text exposition
+// format.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", ContentType)
+	w.Write(h.Metrics())
+}
+
+// Metrics returns the current metrics in the Prometheus text exposition
+// format.
+func (h *Handler) Metrics() []byte {
+	var e exposition
+	h.writeCFGauges(&e)
+	h.writeDBGauges(&e)
+	h.writeLevels(&e)
+	if h.stats != nil {
+		h.writeStatistics(&e)
+	}
+	return e.buf.Bytes()
+}
+
+func (h *Handler) writeCFGauges(e *exposition) {
+	for _, g := range cfGauges {
+		e.family(g.name, g.help, "gauge")
+		if len(h.cfs) == 0 {
+			if v, ok := h.db.GetIntProperty(g.property); ok {
+				e.sample(g.name, []string{"cf", "default"}, formatUint(v))
+			}
+			continue
+		}
+		for i, cf := range h.cfs {
+			if v, ok := h.db.GetIntPropertyCF(g.property, cf); ok {
+				e.sample(g.name, []string{"cf", h.cfNames[i]}, formatUint(v))
+			}
+		}
+	}
+}
+
+func (h *Handler) writeDBGauges(e *exposition) {
+	for _, g := range dbGauges {
+		if v, ok := h.db.GetIntProperty(g.property); ok {
+			e.family(g.name, g.help, "gauge")
+			e.sample(g.name, nil, formatUint(v))
+		}
+	}
+}
+
+func (h *Handler) writeLevels(e *exposition) {
+	files := make(map[string][]uint64)
+	sizes := make(map[string][]uint64)
+	for _, f := range h.db.GetLiveFilesMetaData() {
+		for len(files[f.CFName]) <= f.Level {
+			files[f.CFName] = append(files[f.CFName], 0)
+			sizes[f.CFName] = append(sizes[f.CFName], 0)
+		}
+		files[f.CFName][f.Level]++
+		sizes[f.CFName][f.Level] += uint64(f.Size)
+	}
+	cfNames := make([]string, 0, len(files))
+	for name := range files {
+		cfNames = append(cfNames, name)
+	}
+	sort.Strings(cfNames)
+
+	e.family("rocksdb_sst_files", "Number of live SST files per column family and level.", "gauge")
+	for _, cf := range cfNames {
+		for level, n := range files[cf] {
+			e.sample("rocksdb_sst_files", []string{"cf", cf, "level", strconv.Itoa(level)}, formatUint(n))
+		}
+	}
+	e.family("rocksdb_sst_files_bytes", "Size of the live SST files per column family and level.", "gauge")
+	for _, cf := range cfNames {
+		for level, n := range sizes[cf] {
+			e.sample("rocksdb_sst_files_bytes", []string{"cf", cf, "level", strconv.Itoa(level)}, formatUint(n))
+		}
+	}
+}
+
+func (h *Handler) writeStatistics(e *exposition) {
+	tickers := h.stats.Tickers()
+	tickerNames := make([]string, 0, len(tickers))
+	for name := range tickers {
+		tickerNames = append(tickerNames, string(name))
+	}
+	sort.Strings(tickerNames)
+	for _, name := range tickerNames {
+		metric := sanitizeName(name) + "_total"
+		e.family(metric, "RocksDB ticker "+name+".", "counter")
+		e.sample(metric, nil, formatUint(tickers[gorocksdb.TickerType(name)]))
+	}
+
+	histograms := h.stats.Histograms()
+	histogramNames := make([]string, 0, len(histograms))
+	for name := range histograms {
+		histogramNames = append(histogramNames, string(name))
+	}
+	sort.Strings(histogramNames)
+	for _, name := range histogramNames {
+		data := histograms[gorocksdb.HistogramType(name)]
+		metric := sanitizeName(name)
+		e.family(metric, "RocksDB histogram "+name+".", "summary")
+		e.sample(metric, []string{"quantile", "0.5"}, formatFloat(data.Median))
+		e.sample(metric, []string{"quantile", "0.95"}, formatFloat(data.P95))
+		e.sample(metric, []string{"quantile", "0.99"}, formatFloat(data.P99))
+		e.sample(metric, []string{"quantile", "1"}, formatFloat(data.Max))
+		e.sample(metric+"_sum", nil, formatUint(data.Sum))
+		e.sample(metric+"_count", nil, formatUint(data.Count))
+	}
+}
+
+// exposition builds a document in the Prometheus text exposition format.
+type exposition struct {
+	buf bytes.Buffer
+}
+
+// family writes the HELP and TYPE lines of a metric family.
+func (e *exposition) family(name, help, typ string) {
+	e.buf.WriteString("# HELP ")
+	e.buf.WriteString(name)
+	e.buf.WriteByte(' ')
+	e.buf.WriteString(escapeHelp(help))
+	e.buf.WriteString("\n# TYPE ")
+	e.buf.WriteString(name)
+	e.buf.WriteByte(' ')
+	e.buf.WriteString(typ)
+	e.buf.WriteByte('\n')
+}
+
+// sample writes a sample line. labels holds alternating label names and
+// values.
+func (e *exposition) sample(name string, labels []string, value string) {
+	e.buf.WriteString(name)
+	if len(labels) > 0 {
+		e.buf.WriteByte('{')
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				e.buf.WriteByte(',')
+			}
+			e.buf.WriteString(labels[i])
+			e.buf.WriteString(`="`)
+			e.buf.WriteString(escapeLabelValue(labels[i+1]))
+			e.buf.WriteByte('"')
+		}
+		e.buf.WriteByte('}')
+	}
+	e.buf.WriteByte(' ')
+	e.buf.WriteString(value)
+	e.buf.WriteByte('\n')
+}
+
+var (
+	helpEscaper       = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
+
+func escapeLabelValue(s string) string {
+	return labelValueEscaper.Replace(s)
+}
+
+// sanitizeName converts a RocksDB statistics name such as
+// "rocksdb.block.cache.miss" to a valid metric name.
+func sanitizeName(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || c == ':' || c >= '0' && c <= '9' && i > 0) {
+			b[i] = '_'
+		}
+	}
+	return string(b)
+}
+
+func formatUint(v uint64) string {
+	return strconv.FormatUint(v, 10)
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,104 @@
+package metrics
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/daaku/gorocksdb"
+	"github.com/facebookgo/ensure"
+)
+
+func TestHandler(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gorocksdb-TestHandler")
+	ensure.Nil(t, err)
+
+	opts := gorocksdb.NewOptions()
+	defer opts.Release()
+	opts.SetCreateIfMissing(true)
+	opts.SetCreateIfMissingColumnFamilies(true)
+	opts.EnableStatistics()
+	cfNames := []string{"default", "users"}
+	db, cfs, err := gorocksdb.OpenDBCFs(opts, dir, cfNames, []*gorocksdb.Options{opts, opts})
+	ensure.Nil(t, err)
+	defer db.Release()
+	defer cfs[0].Release()
+	defer cfs[1].Release()
+
+	wo := gorocksdb.NewWriteOptions()
+	defer wo.Release()
+	ensure.Nil(t, db.PutCF(wo, cfs[1], []byte("key1"), []byte("val1")))
+	ensure.Nil(t, db.PutCF(wo, cfs[1], []byte("key2"), []byte("val2")))
+	ensure.Nil(t, db.Put(wo, []byte("key3"), []byte("val3")))
+	fo := gorocksdb.NewFlushOptions()
+	defer fo.Release()
+	ensure.Nil(t, db.Flush(fo))
+
+	_, err = NewHandler(db, cfNames[:1], cfs)
+	ensure.NotNil(t, err)
+	h, err := NewHandler(db, cfNames, cfs)
+	ensure.Nil(t, err)
+	h.SetStatistics(opts.Statistics())
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	res, err := server.Client().Get(server.URL)
+	ensure.Nil(t, err)
+	defer res.Body.Close()
+	ensure.DeepEqual(t, res.Header.Get("Content-Type"), ContentType)
+	body, err := ioutil.ReadAll(res.Body)
+	ensure.Nil(t, err)
+
+	lines := strings.Split(string(body), "\n")
+	ensure.SameElements(t, filter(lines, "rocksdb_memtable_entries"), []string{
+		"# HELP rocksdb_memtable_entries Number of entries in the active memtable.",
+		"# TYPE rocksdb_memtable_entries gauge",
+		`rocksdb_memtable_entries{cf="default"} 0`,
+		`rocksdb_memtable_entries{cf="users"} 2`,
+	})
+	// only the default column family was flushed
+	ensure.SameElements(t, filter(lines, "rocksdb_sst_files"), []string{
+		"# HELP rocksdb_sst_files Number of live SST files per column family and level.",
+		"# TYPE rocksdb_sst_files gauge",
+		`rocksdb_sst_files{cf="default",level="0"} 1`,
+	})
+	ensure.SameElements(t, filter(lines, "rocksdb_number_keys_written_total"), []string{
+		"# HELP rocksdb_number_keys_written_total RocksDB ticker rocksdb.number.keys.written.",
+		"# TYPE rocksdb_number_keys_written_total counter",
+		"rocksdb_number_keys_written_total 3",
+	})
+	ensure.SameElements(t, filter(lines, "rocksdb_db_write_micros_count"), []string{
+		"rocksdb_db_write_micros_count 3",
+	})
+}
+
+func TestExposition(t *testing.T) {
+	var e exposition
+	e.family("rocksdb_test", "Help with \\ and\nnewline.", "gauge")
+	e.sample("rocksdb_test", []string{"cf", "a\"b\\c\nd", "level", "0"}, formatFloat(1.5))
+	e.sample("rocksdb_test", nil, formatUint(2))
+	ensure.DeepEqual(t, e.buf.String(), ""+
+		"# HELP rocksdb_test Help with \\\\ and\\nnewline.\n"+
+		"# TYPE rocksdb_test gauge\n"+
+		"rocksdb_test{cf=\"a\\\"b\\\\c\\nd\",level=\"0\"} 1.5\n"+
+		"rocksdb_test 2\n")
+}
+
+func TestSanitizeName(t *testing.T) {
+	ensure.DeepEqual(t, sanitizeName("rocksdb.block.cache.miss"), "rocksdb_block_cache_miss")
+	ensure.DeepEqual(t, sanitizeName("0rocksdb.l0-hit"), "_rocksdb_l0_hit")
+}
+
+// filter returns the lines of the metric family name.
+func filter(lines []string, name string) []string {
+	var matches []string
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) > 2 && fields[0] == "#" && fields[2] == name ||
+			strings.HasPrefix(line, name+" ") || strings.HasPrefix(line, name+"{") {
+			matches = append(matches, line)
+		}
+	}
+	return matches
+}
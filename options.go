@@ -61,11 +61,15 @@ type Options struct {
 	env  *Env
 	bbto *BlockBasedTableOptions
 
+	// The statistics handle returned by Statistics.
+	stats *Statistics
+
 	// We keep these so we can free their memory in Release.
 	ccmp *C.rocksdb_comparator_t
 	cmo  *C.rocksdb_mergeoperator_t
 	cst  *C.rocksdb_slicetransform_t
 	ccf  *C.rocksdb_compactionfilter_t
+	gccf *C.gorocksdb_compactionfilter_t
 }
 
 // NewOptions creates the default Options.
@@ -75,7 +79,9 @@ func NewOptions() *Options {
 
 // newNativeOptions creates a Options object.
 func newNativeOptions(c *C.rocksdb_options_t) *Options {
-	return &Options{c: c}
+	o := &Options{c: c}
+	trackHandle(o)
+	return o
 }
 
 // -------------------
@@ -87,11 +93,19 @@ func newNativeOptions(c *C.rocksdb_options_t) *Options {
 func (o *Options) SetCompactionFilter(value CompactionFilter) {
 	if nc, ok := value.(nativeCompactionFilter); ok {
 		o.ccf = nc.c
+		C.rocksdb_options_set_compaction_filter(o.c, o.ccf)
 	} else {
-		idx := registerCompactionFilter(value)
-		o.ccf = C.gorocksdb_compactionfilter_create(C.uintptr_t(idx))
+		o.SetCompactionFilterV2(toCompactionFilterV2(value))
 	}
-	C.rocksdb_options_set_compaction_filter(o.c, o.ccf)
+}
+
+// SetCompactionFilterV2 sets the specified compaction filter which will be
+// applied on compactions, see CompactionFilterV2.
+// Default: nil
+func (o *Options) SetCompactionFilterV2(value CompactionFilterV2) {
+	idx := registerCompactionFilter(value)
+	o.gccf = C.gorocksdb_compactionfilter_create(C.uintptr_t(idx))
+	C.gorocksdb_options_set_compaction_filter(o.c, o.gccf)
 }
 
 // SetComparator sets the comparator which define the order of keys in the table.
@@ -152,16 +166,20 @@ func (o *Options) SetCompactionReadAheadSize(size int) {
 //	C.rocksdb_options_set_compaction_filter(o.c, value.filter)
 //}
 
-// This is a factory that provides compaction filter objects which allow
-// an application to modify/delete a key-value during background compaction.
+// SetCompactionFilterFactory sets a factory that provides compaction filter
+// objects which allow an application to modify/delete a key-value during
+// background compaction.
 //
 // A new filter will be created on each compaction run.  If multithreaded
 // compaction is being used, each created CompactionFilter will only be used
 // from a single thread and so does not need to be thread-safe.
 //
+// A compaction filter set with SetCompactionFilter takes precedence.
 // Default: a factory that doesn't provide any object
-// std::shared_ptr<CompactionFilterFactory> compaction_filter_factory;
-// TODO: implement in C and Go
+func (o *Options) SetCompactionFilterFactory(value CompactionFilterFactory) {
+	idx := registerCompactionFilterFactory(value)
+	C.gorocksdb_options_set_compaction_filter_factory(o.c, C.uintptr_t(idx))
+}
 
 // Version TWO of the compaction_filter_factory
 // It supports rolling compaction
@@ -931,7 +949,7 @@ func (o *Options) SetMinPartialMergeOperands(value uint32) {
 	C.rocksdb_options_set_min_partial_merge_operands(o.c, C.uint32_t(value))
 }
 
-// EnableStatistics enable statistics.
+// EnableStatistics enable statistics. They can be read with Statistics.
 func (o *Options) EnableStatistics() {
 	C.rocksdb_options_enable_statistics(o.c)
 }
@@ -1023,7 +1041,12 @@ func (o *Options) Release() {
 	if o.ccf != nil {
 		C.rocksdb_compactionfilter_destroy(o.ccf)
 	}
+	if o.gccf != nil {
+		C.gorocksdb_compactionfilter_destroy(o.gccf)
+	}
 	o.c = nil
 	o.env = nil
 	o.bbto = nil
+	o.stats = nil
+	untrackHandle(o)
 }
@@ -0,0 +1,72 @@
+package gorocksdb
+
+// #include "rocksdb/c.h"
+import "C"
+
+// BottommostLevelCompaction controls whether a manual compaction also
+// compacts the files of the bottommost level.
+type BottommostLevelCompaction uint
+
+const (
+	// BottommostLevelCompactionSkip skips the bottommost level.
+	BottommostLevelCompactionSkip = BottommostLevelCompaction(0)
+	// BottommostLevelCompactionIfHaveCompactionFilter only compacts the
+	// bottommost level if a compaction filter is set.
+	BottommostLevelCompactionIfHaveCompactionFilter = BottommostLevelCompaction(1)
+	// BottommostLevelCompactionForce always compacts the bottommost level.
+	BottommostLevelCompactionForce = BottommostLevelCompaction(2)
+	// BottommostLevelCompactionForceOptimized always compacts the
+	// bottommost level, but skips the files created by this compaction.
+	BottommostLevelCompactionForceOptimized = BottommostLevelCompaction(3)
+)
+
+// CompactRangeOptions represent all of the available options for a manual
+// compaction with CompactRangeWithOptions.
+type CompactRangeOptions struct {
+	c *C.rocksdb_compactoptions_t
+}
+
+// NewCompactRangeOptions creates a default CompactRangeOptions object.
+func NewCompactRangeOptions() *CompactRangeOptions {
+	return newNativeCompactRangeOptions(C.rocksdb_compactoptions_create())
+}
+
+// newNativeCompactRangeOptions creates a CompactRangeOptions object.
+func newNativeCompactRangeOptions(c *C.rocksdb_compactoptions_t) *CompactRangeOptions {
+	return &CompactRangeOptions{c}
+}
+
+// SetExclusiveManualCompaction specify if no other compaction may run
+// concurrently with the manual compaction.
+// Default: true
+func (o *CompactRangeOptions) SetExclusiveManualCompaction(value bool) {
+	C.rocksdb_compactoptions_set_exclusive_manual_compaction(o.c, boolToChar(value))
+}
+
+// SetChangeLevel specify if the compacted files should be moved to the
+// level set by SetTargetLevel.
+// Default: false
+func (o *CompactRangeOptions) SetChangeLevel(value bool) {
+	C.rocksdb_compactoptions_set_change_level(o.c, boolToChar(value))
+}
+
+// SetTargetLevel sets the level the compacted files are moved to if
+// SetChangeLevel is set. A negative level moves them to the minimum level
+// able to hold the data.
+// Default: -1
+func (o *CompactRangeOptions) SetTargetLevel(value int) {
+	C.rocksdb_compactoptions_set_target_level(o.c, C.int(value))
+}
+
+// SetBottommostLevelCompaction sets the policy for compacting the
+// bottommost level.
+// Default: BottommostLevelCompactionIfHaveCompactionFilter
+func (o *CompactRangeOptions) SetBottommostLevelCompaction(value BottommostLevelCompaction) {
+	C.rocksdb_compactoptions_set_bottommost_level_compaction(o.c, C.uchar(value))
+}
+
+// Release deallocates the CompactRangeOptions object.
+func (o *CompactRangeOptions) Release() {
+	C.rocksdb_compactoptions_destroy(o.c)
+	o.c = nil
+}
@@ -0,0 +1,26 @@
+package gorocksdb
+
+// #include "rocksdb/c.h"
+import "C"
+
+// EnvOptions represent the options used when reading and writing files
+// outside of a database, e.g. by a SstFileWriter.
+type EnvOptions struct {
+	c *C.rocksdb_envoptions_t
+}
+
+// NewEnvOptions creates a default EnvOptions object.
+func NewEnvOptions() *EnvOptions {
+	return newNativeEnvOptions(C.rocksdb_envoptions_create())
+}
+
+// newNativeEnvOptions creates a EnvOptions object.
+func newNativeEnvOptions(c *C.rocksdb_envoptions_t) *EnvOptions {
+	return &EnvOptions{c}
+}
+
+// Release deallocates the EnvOptions object.
+func (o *EnvOptions) Release() {
+	C.rocksdb_envoptions_destroy(o.c)
+	o.c = nil
+}
@@ -0,0 +1,67 @@
+package gorocksdb
+
+// #include "rocksdb/c.h"
+import "C"
+
+// IngestExternalFileOptions represent all of the available options when
+// ingesting external SST files into a database.
+type IngestExternalFileOptions struct {
+	c *C.rocksdb_ingestexternalfileoptions_t
+}
+
+// NewIngestExternalFileOptions creates a default IngestExternalFileOptions
+// object.
+func NewIngestExternalFileOptions() *IngestExternalFileOptions {
+	return newNativeIngestExternalFileOptions(C.rocksdb_ingestexternalfileoptions_create())
+}
+
+// newNativeIngestExternalFileOptions creates a IngestExternalFileOptions
+// object.
+func newNativeIngestExternalFileOptions(c *C.rocksdb_ingestexternalfileoptions_t) *IngestExternalFileOptions {
+	return &IngestExternalFileOptions{c}
+}
+
+// SetMoveFiles specifies whether the external files should be moved
+// (hard linked) into the database instead of copied.
+// Default: false
+func (o *IngestExternalFileOptions) SetMoveFiles(value bool) {
+	C.rocksdb_ingestexternalfileoptions_set_move_files(o.c, boolToChar(value))
+}
+
+// SetSnapshotConsistency specifies whether snapshots taken before the
+// ingestion keep their view of the data. If false, the ingested keys become
+// visible to existing snapshots and the ingestion may be faster.
+// Default: true
+func (o *IngestExternalFileOptions) SetSnapshotConsistency(value bool) {
+	C.rocksdb_ingestexternalfileoptions_set_snapshot_consistency(o.c, boolToChar(value))
+}
+
+// SetAllowGlobalSeqNo specifies whether a global sequence number may be
+// assigned to the ingested files. If false, ingesting files whose key range
+// overlaps existing data fails.
+// Default: true
+func (o *IngestExternalFileOptions) SetAllowGlobalSeqNo(value bool) {
+	C.rocksdb_ingestexternalfileoptions_set_allow_global_seqno(o.c, boolToChar(value))
+}
+
+// SetAllowBlockingFlush specifies whether the ingestion may flush the
+// memtable if it overlaps the key range of the ingested files. If false,
+// the ingestion fails in that case.
+// Default: true
+func (o *IngestExternalFileOptions) SetAllowBlockingFlush(value bool) {
+	C.rocksdb_ingestexternalfileoptions_set_allow_blocking_flush(o.c, boolToChar(value))
+}
+
+// SetIngestBehind specifies whether the files should be ingested at the
+// bottommost level, behind all existing data, so that duplicate keys are
+// skipped. This requires the database to be opened with allow_ingest_behind.
+// Default: false
+func (o *IngestExternalFileOptions) SetIngestBehind(value bool) {
+	C.rocksdb_ingestexternalfileoptions_set_ingest_behind(o.c, boolToChar(value))
+}
+
+// Release deallocates the IngestExternalFileOptions object.
+func (o *IngestExternalFileOptions) Release() {
+	C.rocksdb_ingestexternalfileoptions_destroy(o.c)
+	o.c = nil
+}
@@ -1,7 +1,9 @@
 package gorocksdb
 
+// #include <stdlib.h>
 // #include "rocksdb/c.h"
 import "C"
+import "unsafe"
 
 // ReadTier controls fetching of data during a read request.
 // An application can issue a read request (via Get/Iterators) and specify
@@ -23,6 +25,14 @@ const (
 // database.
 type ReadOptions struct {
 	c *C.rocksdb_readoptions_t
+
+	hideExpired bool
+
+	// RocksDB only references the iterate bounds, so they are copied to C
+	// memory which lives until the bound is replaced or the options are
+	// released.
+	upperBound *C.char
+	lowerBound *C.char
 }
 
 // NewReadOptions creates a default ReadOptions object.
@@ -32,7 +42,9 @@ func NewReadOptions() *ReadOptions {
 
 // newNativeReadOptions creates a ReadOptions object.
 func newNativeReadOptions(c *C.rocksdb_readoptions_t) *ReadOptions {
-	return &ReadOptions{c}
+	o := &ReadOptions{c: c}
+	trackHandle(o)
+	return o
 }
 
 // SetVerifyChecksums speciy if all data read from underlying storage will be
@@ -76,8 +88,75 @@ func (o *ReadOptions) SetTailing(value bool) {
 	C.rocksdb_readoptions_set_tailing(o.c, boolToChar(value))
 }
 
+// SetHideExpired specify if reads from a database opened with a TTL should
+// skip entries which have expired but were not dropped by a compaction
+// yet. It applies to Get, GetCF and iterators, at the cost of checking the
+// write timestamp of every value read.
+// Default: false
+func (o *ReadOptions) SetHideExpired(value bool) {
+	o.hideExpired = value
+}
+
+// SetIterateUpperBound sets the key at which iterators stop: they become
+// invalid before reaching it instead of reading past it. The bound is
+// exclusive and copied, so the slice may be reused. A nil or empty key
+// removes the bound.
+//
+// Iterators read the bounds from the options they were created with, so
+// they must be released before the options are released or their bounds
+// are changed.
+// Default: nil
+func (o *ReadOptions) SetIterateUpperBound(key []byte) {
+	cKey := cByteSlice(key)
+	C.rocksdb_readoptions_set_iterate_upper_bound(o.c, cKey, C.size_t(len(key)))
+	C.free(unsafe.Pointer(o.upperBound))
+	o.upperBound = cKey
+}
+
+// SetIterateLowerBound sets the key below which iterators become invalid
+// when moving backward. The bound is inclusive and copied, so the slice may
+// be reused. A nil or empty key removes the bound. As with
+// SetIterateUpperBound, iterators created from the options must be released
+// before the bound is changed.
+// Default: nil
+func (o *ReadOptions) SetIterateLowerBound(key []byte) {
+	cKey := cByteSlice(key)
+	C.rocksdb_readoptions_set_iterate_lower_bound(o.c, cKey, C.size_t(len(key)))
+	C.free(unsafe.Pointer(o.lowerBound))
+	o.lowerBound = cKey
+}
+
+// SetPrefixSameAsStart specify if iterators should only return keys with
+// the same prefix as the key they were seeked to, according to the prefix
+// extractor of the column family. Iterators then become invalid at the end
+// of the prefix, without the need for ValidForPrefix.
+// Default: false
+func (o *ReadOptions) SetPrefixSameAsStart(value bool) {
+	C.rocksdb_readoptions_set_prefix_same_as_start(o.c, boolToChar(value))
+}
+
+// SetTotalOrderSeek specify if iterators should ignore the prefix extractor
+// and return all keys in order, bypassing prefix bloom filters.
+// Default: false
+func (o *ReadOptions) SetTotalOrderSeek(value bool) {
+	C.rocksdb_readoptions_set_total_order_seek(o.c, boolToChar(value))
+}
+
+// SetPinData specify if the blocks loaded by iterators should stay in
+// memory as long as the iterator, which keeps the data returned by Key
+// valid until the iterator is released.
+// Default: false
+func (o *ReadOptions) SetPinData(value bool) {
+	C.rocksdb_readoptions_set_pin_data(o.c, boolToChar(value))
+}
+
 // Release deallocates the ReadOptions object.
 func (o *ReadOptions) Release() {
 	C.rocksdb_readoptions_destroy(o.c)
 	o.c = nil
+	C.free(unsafe.Pointer(o.upperBound))
+	o.upperBound = nil
+	C.free(unsafe.Pointer(o.lowerBound))
+	o.lowerBound = nil
+	untrackHandle(o)
 }
@@ -0,0 +1,166 @@
+package gorocksdb
+
+// #include "rocksdb/c.h"
+import "C"
+
+// TransactionDBOptions represent all of the available options when opening
+// a database with OpenTransactionDB.
+type TransactionDBOptions struct {
+	c *C.rocksdb_transactiondb_options_t
+}
+
+// NewTransactionDBOptions creates a default TransactionDBOptions object.
+func NewTransactionDBOptions() *TransactionDBOptions {
+	return newNativeTransactionDBOptions(C.rocksdb_transactiondb_options_create())
+}
+
+// newNativeTransactionDBOptions creates a TransactionDBOptions object.
+func newNativeTransactionDBOptions(c *C.rocksdb_transactiondb_options_t) *TransactionDBOptions {
+	return &TransactionDBOptions{c}
+}
+
+// SetMaxNumLocks sets the maximum number of keys that can be locked at the
+// same time per column family.
+// If the number of locked keys is greater than this value, any
+// GetForUpdate, Put or Delete will fail with a conflict error.
+// If this value is not positive, no limit will be enforced.
+// Default: -1
+func (o *TransactionDBOptions) SetMaxNumLocks(value int64) {
+	C.rocksdb_transactiondb_options_set_max_num_locks(o.c, C.int64_t(value))
+}
+
+// SetNumStripes sets the number of sub-tables per column family used to
+// increase concurrency of the lock manager. Increasing this value will
+// increase the concurrency by dividing the lock table into more sub-tables.
+// Default: 16
+func (o *TransactionDBOptions) SetNumStripes(value int) {
+	C.rocksdb_transactiondb_options_set_num_stripes(o.c, C.size_t(value))
+}
+
+// SetTransactionLockTimeout sets the default wait timeout in milliseconds
+// when a transaction attempts to lock a key if not specified by
+// TransactionOptions.SetLockTimeout.
+// If 0, no waiting is done if a lock cannot instantly be acquired.
+// If negative, there is no timeout. Not using a timeout is not recommended
+// as it can lead to deadlocks.
+// Default: 1000
+func (o *TransactionDBOptions) SetTransactionLockTimeout(value int64) {
+	C.rocksdb_transactiondb_options_set_transaction_lock_timeout(o.c, C.int64_t(value))
+}
+
+// SetDefaultLockTimeout sets the wait timeout in milliseconds when writing
+// a key outside of a transaction (e.g. TransactionDB.Put) if the key is
+// currently locked by a transaction.
+// If 0, no waiting is done if a lock cannot instantly be acquired.
+// If negative, there is no timeout and will block indefinitely when
+// acquiring a lock.
+// Default: 1000
+func (o *TransactionDBOptions) SetDefaultLockTimeout(value int64) {
+	C.rocksdb_transactiondb_options_set_default_lock_timeout(o.c, C.int64_t(value))
+}
+
+// Release deallocates the TransactionDBOptions object.
+func (o *TransactionDBOptions) Release() {
+	C.rocksdb_transactiondb_options_destroy(o.c)
+	o.c = nil
+}
+
+// TransactionOptions represent all of the available options when beginning
+// a transaction on a TransactionDB.
+type TransactionOptions struct {
+	c *C.rocksdb_transaction_options_t
+}
+
+// NewTransactionOptions creates a default TransactionOptions object.
+func NewTransactionOptions() *TransactionOptions {
+	return newNativeTransactionOptions(C.rocksdb_transaction_options_create())
+}
+
+// newNativeTransactionOptions creates a TransactionOptions object.
+func newNativeTransactionOptions(c *C.rocksdb_transaction_options_t) *TransactionOptions {
+	return &TransactionOptions{c}
+}
+
+// SetSetSnapshot specifies whether a snapshot is taken when the transaction
+// begins. If true, writes of keys changed by other transactions after the
+// snapshot was taken cause the transaction to fail with a conflict error.
+// Default: false
+func (o *TransactionOptions) SetSetSnapshot(value bool) {
+	C.rocksdb_transaction_options_set_set_snapshot(o.c, boolToChar(value))
+}
+
+// SetDeadlockDetect specifies whether to check for deadlocks when waiting
+// for a lock.
+// Default: false
+func (o *TransactionOptions) SetDeadlockDetect(value bool) {
+	C.rocksdb_transaction_options_set_deadlock_detect(o.c, boolToChar(value))
+}
+
+// SetLockTimeout sets the wait timeout in milliseconds when the transaction
+// attempts to lock a key. If negative, TransactionDBOptions'
+// TransactionLockTimeout will be used.
+// Default: -1
+func (o *TransactionOptions) SetLockTimeout(value int64) {
+	C.rocksdb_transaction_options_set_lock_timeout(o.c, C.int64_t(value))
+}
+
+// SetExpiration sets the time in milliseconds after which the transaction
+// expires. Expired transactions can no longer be committed and their locks
+// may be stolen by other transactions. If negative, the transaction does
+// not expire.
+// Default: -1
+func (o *TransactionOptions) SetExpiration(value int64) {
+	C.rocksdb_transaction_options_set_expiration(o.c, C.int64_t(value))
+}
+
+// SetDeadlockDetectDepth sets the number of traversals to make during
+// deadlock detection.
+// Default: 50
+func (o *TransactionOptions) SetDeadlockDetectDepth(value int64) {
+	C.rocksdb_transaction_options_set_deadlock_detect_depth(o.c, C.int64_t(value))
+}
+
+// SetMaxWriteBatchSize sets the maximum number of bytes used for the write
+// batch of the transaction. 0 means no limit.
+// Default: 0
+func (o *TransactionOptions) SetMaxWriteBatchSize(value int) {
+	C.rocksdb_transaction_options_set_max_write_batch_size(o.c, C.size_t(value))
+}
+
+// Release deallocates the TransactionOptions object.
+func (o *TransactionOptions) Release() {
+	C.rocksdb_transaction_options_destroy(o.c)
+	o.c = nil
+}
+
+// OptimisticTransactionOptions represent all of the available options when
+// beginning a transaction on an OptimisticTransactionDB.
+type OptimisticTransactionOptions struct {
+	c *C.rocksdb_optimistictransaction_options_t
+}
+
+// NewOptimisticTransactionOptions creates a default
+// OptimisticTransactionOptions object.
+func NewOptimisticTransactionOptions() *OptimisticTransactionOptions {
+	return newNativeOptimisticTransactionOptions(C.rocksdb_optimistictransaction_options_create())
+}
+
+// newNativeOptimisticTransactionOptions creates a
+// OptimisticTransactionOptions object.
+func newNativeOptimisticTransactionOptions(c *C.rocksdb_optimistictransaction_options_t) *OptimisticTransactionOptions {
+	return &OptimisticTransactionOptions{c}
+}
+
+// SetSetSnapshot specifies whether a snapshot is taken when the transaction
+// begins. If true, the transaction fails to commit with a conflict error if
+// any key it wrote was changed by someone else after the snapshot.
+// Default: false
+func (o *OptimisticTransactionOptions) SetSetSnapshot(value bool) {
+	C.rocksdb_optimistictransaction_options_set_set_snapshot(o.c, boolToChar(value))
+}
+
+// Release deallocates the OptimisticTransactionOptions object.
+func (o *OptimisticTransactionOptions) Release() {
+	C.rocksdb_optimistictransaction_options_destroy(o.c)
+	o.c = nil
+}
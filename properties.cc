@@ -0,0 +1,41 @@
+#include <map>
+#include <string>
+#include <vector>
+
+#include "gorocksdb_internal.h"
+
+using rocksdb::Slice;
+
+// Owns the map the C view points into.
+struct gorocksdb_map_property_holder_t : public gorocksdb_map_property_t {
+    std::map<std::string, std::string> entries;
+    std::vector<const char*> key_ptrs;
+    std::vector<size_t> key_sizes;
+    std::vector<const char*> value_ptrs;
+    std::vector<size_t> value_sizes;
+};
+
+gorocksdb_map_property_t* gorocksdb_property_map(rocksdb_t* db, rocksdb_column_family_handle_t* cf, const char* propname, size_t propname_len) {
+    gorocksdb_map_property_holder_t* m = new gorocksdb_map_property_holder_t();
+    rocksdb::ColumnFamilyHandle* handle = cf != NULL ? cf->rep : db->rep->DefaultColumnFamily();
+    if (!db->rep->GetMapProperty(handle, Slice(propname, propname_len), &m->entries)) {
+        delete m;
+        return NULL;
+    }
+    for (const auto& entry : m->entries) {
+        m->key_ptrs.push_back(entry.first.data());
+        m->key_sizes.push_back(entry.first.size());
+        m->value_ptrs.push_back(entry.second.data());
+        m->value_sizes.push_back(entry.second.size());
+    }
+    m->keys = m->key_ptrs.data();
+    m->key_lens = m->key_sizes.data();
+    m->values = m->value_ptrs.data();
+    m->value_lens = m->value_sizes.data();
+    m->num_entries = m->entries.size();
+    return m;
+}
+
+void gorocksdb_map_property_destroy(gorocksdb_map_property_t* property) {
+    delete static_cast<gorocksdb_map_property_holder_t*>(property);
+}
@@ -0,0 +1,140 @@
+package gorocksdb
+
+// Names of database properties which can be read with DB.GetProperty. The
+// ones documented as numeric can also be read with DB.GetIntProperty, and
+// the ones with a map form, such as PropertyCFStats, with DB.GetMapProperty.
+const (
+	// PropertyNumFilesAtLevelPrefix followed by a level number is the
+	// number of files at that level, e.g. "rocksdb.num-files-at-level0".
+	// Numeric.
+	PropertyNumFilesAtLevelPrefix = "rocksdb.num-files-at-level"
+	// PropertyCompressionRatioAtLevelPrefix followed by a level number is
+	// the compression ratio of the data at that level.
+	PropertyCompressionRatioAtLevelPrefix = "rocksdb.compression-ratio-at-level"
+	// PropertyStats is a multi-line string of the database and column
+	// family statistics.
+	PropertyStats = "rocksdb.stats"
+	// PropertySSTables is a multi-line string summarizing the SST files.
+	PropertySSTables = "rocksdb.sstables"
+	// PropertyCFStats is a multi-line string of the column family
+	// statistics.
+	PropertyCFStats = "rocksdb.cfstats"
+	// PropertyCFStatsNoFileHistogram is PropertyCFStats without the file
+	// read latency histogram.
+	PropertyCFStatsNoFileHistogram = "rocksdb.cfstats-no-file-histogram"
+	// PropertyCFFileHistogram is a multi-line string of the file read
+	// latency histogram.
+	PropertyCFFileHistogram = "rocksdb.cf-file-histogram"
+	// PropertyDBStats is a multi-line string of the database statistics.
+	PropertyDBStats = "rocksdb.dbstats"
+	// PropertyLevelStats is a multi-line string of the number of files
+	// and their size per level.
+	PropertyLevelStats = "rocksdb.levelstats"
+	// PropertyAggregatedTableProperties is the aggregated properties of
+	// all SST files. Key-value pairs.
+	PropertyAggregatedTableProperties = "rocksdb.aggregated-table-properties"
+	// PropertyAggregatedTablePropertiesAtLevelPrefix followed by a level
+	// number is the aggregated properties of the SST files at that level.
+	// Key-value pairs.
+	PropertyAggregatedTablePropertiesAtLevelPrefix = "rocksdb.aggregated-table-properties-at-level"
+	// PropertyOptionsStatistics is a multi-line string of the statistics
+	// enabled with Options.EnableStatistics.
+	PropertyOptionsStatistics = "rocksdb.options-statistics"
+
+	// PropertyNumImmutableMemTable is the number of immutable memtables
+	// not yet flushed. Numeric.
+	PropertyNumImmutableMemTable = "rocksdb.num-immutable-mem-table"
+	// PropertyNumImmutableMemTableFlushed is the number of immutable
+	// memtables already flushed. Numeric.
+	PropertyNumImmutableMemTableFlushed = "rocksdb.num-immutable-mem-table-flushed"
+	// PropertyMemTableFlushPending is 1 if a memtable flush is pending.
+	// Numeric.
+	PropertyMemTableFlushPending = "rocksdb.mem-table-flush-pending"
+	// PropertyNumRunningFlushes is the number of currently running
+	// flushes. Numeric.
+	PropertyNumRunningFlushes = "rocksdb.num-running-flushes"
+	// PropertyCompactionPending is 1 if at least one compaction is
+	// pending. Numeric.
+	PropertyCompactionPending = "rocksdb.compaction-pending"
+	// PropertyNumRunningCompactions is the number of currently running
+	// compactions. Numeric.
+	PropertyNumRunningCompactions = "rocksdb.num-running-compactions"
+	// PropertyBackgroundErrors is the accumulated number of background
+	// errors. Numeric.
+	PropertyBackgroundErrors = "rocksdb.background-errors"
+	// PropertyCurSizeActiveMemTable is the approximate size in bytes of
+	// the active memtable. Numeric.
+	PropertyCurSizeActiveMemTable = "rocksdb.cur-size-active-mem-table"
+	// PropertyCurSizeAllMemTables is the approximate size in bytes of the
+	// active and unflushed immutable memtables. Numeric.
+	PropertyCurSizeAllMemTables = "rocksdb.cur-size-all-mem-tables"
+	// PropertySizeAllMemTables is the approximate size in bytes of the
+	// active, unflushed immutable and pinned immutable memtables. Numeric.
+	PropertySizeAllMemTables = "rocksdb.size-all-mem-tables"
+	// PropertyNumEntriesActiveMemTable is the number of entries in the
+	// active memtable. Numeric.
+	PropertyNumEntriesActiveMemTable = "rocksdb.num-entries-active-mem-table"
+	// PropertyNumEntriesImmMemTables is the number of entries in the
+	// unflushed immutable memtables. Numeric.
+	PropertyNumEntriesImmMemTables = "rocksdb.num-entries-imm-mem-tables"
+	// PropertyNumDeletesActiveMemTable is the number of deletions in the
+	// active memtable. Numeric.
+	PropertyNumDeletesActiveMemTable = "rocksdb.num-deletes-active-mem-table"
+	// PropertyNumDeletesImmMemTables is the number of deletions in the
+	// unflushed immutable memtables. Numeric.
+	PropertyNumDeletesImmMemTables = "rocksdb.num-deletes-imm-mem-tables"
+	// PropertyEstimateNumKeys is the estimated number of keys. Numeric.
+	PropertyEstimateNumKeys = "rocksdb.estimate-num-keys"
+	// PropertyEstimateTableReadersMem is the estimated memory in bytes used
+	// for reading SST files, excluding the block cache. Numeric.
+	PropertyEstimateTableReadersMem = "rocksdb.estimate-table-readers-mem"
+	// PropertyIsFileDeletionsEnabled is 0 if the deletion of obsolete
+	// files is disabled. Numeric.
+	PropertyIsFileDeletionsEnabled = "rocksdb.is-file-deletions-enabled"
+	// PropertyNumSnapshots is the number of unreleased snapshots. Numeric.
+	PropertyNumSnapshots = "rocksdb.num-snapshots"
+	// PropertyOldestSnapshotTime is the unix timestamp of the oldest
+	// unreleased snapshot. Numeric.
+	PropertyOldestSnapshotTime = "rocksdb.oldest-snapshot-time"
+	// PropertyNumLiveVersions is the number of live versions. Numeric.
+	PropertyNumLiveVersions = "rocksdb.num-live-versions"
+	// PropertyCurrentSuperVersionNumber is the number of the current
+	// super version, incremented on every change of the LSM tree. Numeric.
+	PropertyCurrentSuperVersionNumber = "rocksdb.current-super-version-number"
+	// PropertyEstimateLiveDataSize is the estimated size in bytes of the
+	// live data. Numeric.
+	PropertyEstimateLiveDataSize = "rocksdb.estimate-live-data-size"
+	// PropertyMinLogNumberToKeep is the minimum number of the log files
+	// which must be kept. Numeric.
+	PropertyMinLogNumberToKeep = "rocksdb.min-log-number-to-keep"
+	// PropertyTotalSSTFilesSize is the total size in bytes of all SST
+	// files, including obsolete ones. Numeric.
+	PropertyTotalSSTFilesSize = "rocksdb.total-sst-files-size"
+	// PropertyLiveSSTFilesSize is the total size in bytes of the SST
+	// files of the current version. Numeric.
+	PropertyLiveSSTFilesSize = "rocksdb.live-sst-files-size"
+	// PropertyBaseLevel is the level to which level 0 data is compacted.
+	// Numeric.
+	PropertyBaseLevel = "rocksdb.base-level"
+	// PropertyEstimatePendingCompactionBytes is the estimated number of
+	// bytes compaction needs to rewrite to get all levels below their
+	// target size. Numeric.
+	PropertyEstimatePendingCompactionBytes = "rocksdb.estimate-pending-compaction-bytes"
+	// PropertyActualDelayedWriteRate is the current rate in bytes per
+	// second writes are delayed to, 0 if they are not delayed. Numeric.
+	PropertyActualDelayedWriteRate = "rocksdb.actual-delayed-write-rate"
+	// PropertyIsWriteStopped is 1 if writes are stopped. Numeric.
+	PropertyIsWriteStopped = "rocksdb.is-write-stopped"
+	// PropertyEstimateOldestKeyTime is the estimated unix timestamp of the
+	// oldest key. Numeric.
+	PropertyEstimateOldestKeyTime = "rocksdb.estimate-oldest-key-time"
+	// PropertyBlockCacheCapacity is the capacity in bytes of the block
+	// cache. Numeric.
+	PropertyBlockCacheCapacity = "rocksdb.block-cache-capacity"
+	// PropertyBlockCacheUsage is the memory in bytes used by entries in
+	// the block cache. Numeric.
+	PropertyBlockCacheUsage = "rocksdb.block-cache-usage"
+	// PropertyBlockCachePinnedUsage is the memory in bytes used by pinned
+	// entries in the block cache. Numeric.
+	PropertyBlockCachePinnedUsage = "rocksdb.block-cache-pinned-usage"
+)
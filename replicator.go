@@ -0,0 +1,207 @@
+package gorocksdb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// replicatorSequenceKey is the key in the state column family holding the
+// last leader sequence number applied to the follower.
+var replicatorSequenceKey = []byte("applied_sequence")
+
+// replicationFrameHeaderSize is the size of the header preceding every
+// write batch on the wire: the leader sequence number of the batch and the
+// size of its data.
+const replicationFrameHeaderSize = 8 + 4
+
+// DefaultMaxReplicationFrameSize is the default size of the largest write
+// batch a Replicator accepts, see Replicator.SetMaxFrameSize.
+const DefaultMaxReplicationFrameSize = 64 << 20
+
+// SendUpdatesSince writes all updates committed to the leader database
+// starting with sequence number seq to w, as read by Replicator.Apply. It
+// returns the sequence number to continue from once more updates have been
+// committed.
+//
+// Updates are read from the write ahead log of the leader, see
+// DB.GetUpdatesSince for how long they are available.
+func SendUpdatesSince(leader *DB, seq uint64, w io.Writer) (uint64, error) {
+	if seq > leader.GetLatestSequenceNumber() {
+		return seq, nil
+	}
+	it, err := leader.GetUpdatesSince(seq)
+	if err != nil {
+		return seq, err
+	}
+	defer it.Release()
+
+	var header [replicationFrameHeaderSize]byte
+	for ; it.Valid(); it.Next() {
+		batch, batchSeq := it.GetBatch()
+		data := batch.Data()
+		binary.BigEndian.PutUint64(header[:8], batchSeq)
+		binary.BigEndian.PutUint32(header[8:], uint32(len(data)))
+		_, err := w.Write(header[:])
+		if err == nil {
+			_, err = w.Write(data)
+		}
+		next := batchSeq + uint64(batch.Count())
+		batch.Release()
+		if err != nil {
+			return seq, err
+		}
+		if next > seq {
+			seq = next
+		}
+	}
+	return seq, it.Err()
+}
+
+// Replicator applies updates streamed from a leader database to a follower
+// database. The last applied leader sequence number is stored in a
+// dedicated column family of the follower, written atomically with every
+// update, so replication can resume after a restart.
+//
+// Updates are applied with the column family ids of the leader, so the
+// follower must have the same column families as the leader, created in
+// the same order, with the state column family created after them.
+type Replicator struct {
+	follower *DB
+	stateCF  *CF
+	ro       *ReadOptions
+	wo       *WriteOptions
+
+	maxFrameSize int
+	buf          []byte
+}
+
+// NewReplicator creates a Replicator object applying updates to follower
+// and keeping its state in stateCF.
+func NewReplicator(follower *DB, stateCF *CF) *Replicator {
+	return &Replicator{
+		follower: follower,
+		stateCF:  stateCF,
+		ro:       NewReadOptions(),
+		wo:       NewWriteOptions(),
+
+		maxFrameSize: DefaultMaxReplicationFrameSize,
+	}
+}
+
+// SetMaxFrameSize sets the size in bytes of the largest write batch Apply
+// accepts, which bounds the memory used for a corrupted or untrusted
+// stream. It must be at least the size of the largest write batch
+// committed to the leader.
+// Default: DefaultMaxReplicationFrameSize
+func (r *Replicator) SetMaxFrameSize(size int) {
+	r.maxFrameSize = size
+}
+
+// AppliedSequence returns the last leader sequence number applied to the
+// follower, or 0 if nothing was applied yet.
+func (r *Replicator) AppliedSequence() (uint64, error) {
+	value, err := r.follower.GetCF(r.ro, r.stateCF, replicatorSequenceKey)
+	if err != nil {
+		return 0, err
+	}
+	defer value.Release()
+	data := value.Data()
+	if data == nil {
+		return 0, nil
+	}
+	if len(data) != 8 {
+		return 0, fmt.Errorf("gorocksdb: invalid applied sequence of %d bytes", len(data))
+	}
+	return binary.BigEndian.Uint64(data), nil
+}
+
+// Apply reads updates written by SendUpdatesSince from src until EOF and
+// applies them to the follower. Updates which were already applied are
+// skipped; a TransactionLogGapError is returned if updates are missing.
+func (r *Replicator) Apply(src io.Reader) error {
+	applied, err := r.AppliedSequence()
+	if err != nil {
+		return err
+	}
+
+	var header [replicationFrameHeaderSize]byte
+	for {
+		if _, err := io.ReadFull(src, header[:]); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		seq := binary.BigEndian.Uint64(header[:8])
+		size := int64(binary.BigEndian.Uint32(header[8:]))
+		if size > int64(r.maxFrameSize) {
+			return fmt.Errorf("gorocksdb: replication frame of %d bytes exceeds the maximum of %d bytes", size, r.maxFrameSize)
+		}
+		if int64(cap(r.buf)) < size {
+			r.buf = make([]byte, size)
+		}
+		data := r.buf[:size]
+		if _, err := io.ReadFull(src, data); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return err
+		}
+
+		batch := WriteBatchFrom(data)
+		count := uint64(batch.Count())
+		if seq+count <= applied+1 {
+			batch.Release()
+			continue
+		}
+		if seq != applied+1 {
+			batch.Release()
+			return &TransactionLogGapError{Expected: applied + 1, Actual: seq}
+		}
+		applied = seq + count - 1
+
+		var value [8]byte
+		binary.BigEndian.PutUint64(value[:], applied)
+		batch.PutCF(r.stateCF, replicatorSequenceKey, value[:])
+		err := r.follower.Write(r.wo, batch)
+		batch.Release()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Sync applies all updates committed to the leader database since the last
+// applied one to the follower, streaming them through an in-memory pipe.
+func (r *Replicator) Sync(leader *DB) error {
+	applied, err := r.AppliedSequence()
+	if err != nil {
+		return err
+	}
+
+	pr, pw := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, err := SendUpdatesSince(leader, applied+1, pw)
+		pw.CloseWithError(err)
+	}()
+	err = r.Apply(pr)
+	pr.CloseWithError(errReplicatorClosed)
+	<-done
+	return err
+}
+
+// errReplicatorClosed stops the sender of Sync if applying failed.
+var errReplicatorClosed = errors.New("gorocksdb: replicator closed")
+
+// Release releases the resources held by the Replicator. It does not
+// release the follower database or the state column family.
+func (r *Replicator) Release() {
+	r.ro.Release()
+	r.wo.Release()
+	r.ro = nil
+	r.wo = nil
+}
@@ -0,0 +1,115 @@
+package gorocksdb
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func newTestReplicator(t *testing.T, name string) (*DB, *Replicator) {
+	dir, err := ioutil.TempDir("", "gorocksdb-"+name)
+	ensure.Nil(t, err)
+
+	opts := NewOptions()
+	t.Cleanup(opts.Release)
+	opts.SetCreateIfMissing(true)
+	opts.SetCreateIfMissingColumnFamilies(true)
+	db, cfs, err := OpenDBCFs(opts, dir, []string{"default", "replication"}, []*Options{opts, opts})
+	ensure.Nil(t, err)
+	cfs[0].Release()
+	return db, NewReplicator(db, cfs[1])
+}
+
+func TestReplicatorSync(t *testing.T) {
+	leader := newTestDB(t, "TestReplicatorSyncLeader", func(opts *Options) {
+		opts.SetWALTtlSeconds(3600)
+	})
+	defer leader.Release()
+	follower, r := newTestReplicator(t, "TestReplicatorSyncFollower")
+	defer follower.Release()
+	defer r.stateCF.Release()
+	defer r.Release()
+
+	wo := NewWriteOptions()
+	defer wo.Release()
+	ro := NewReadOptions()
+	defer ro.Release()
+	ensure.Nil(t, leader.Put(wo, []byte("key1"), []byte("val1")))
+	ensure.Nil(t, leader.Put(wo, []byte("key2"), []byte("val2")))
+	ensure.Nil(t, r.Sync(leader))
+
+	applied, err := r.AppliedSequence()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, applied, leader.GetLatestSequenceNumber())
+	v, err := follower.Get(ro, []byte("key2"))
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v.Data(), []byte("val2"))
+	v.Release()
+
+	// resumes from the applied sequence
+	ensure.Nil(t, leader.Delete(wo, []byte("key1")))
+	ensure.Nil(t, r.Sync(leader))
+	ensure.Nil(t, r.Sync(leader))
+	applied, err = r.AppliedSequence()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, applied, uint64(3))
+	v, err = follower.Get(ro, []byte("key1"))
+	ensure.Nil(t, err)
+	ensure.True(t, v.Data() == nil)
+}
+
+func TestReplicatorApply(t *testing.T) {
+	leader := newTestDB(t, "TestReplicatorApplyLeader", func(opts *Options) {
+		opts.SetWALTtlSeconds(3600)
+	})
+	defer leader.Release()
+	follower, r := newTestReplicator(t, "TestReplicatorApplyFollower")
+	defer follower.Release()
+	defer r.stateCF.Release()
+	defer r.Release()
+
+	wo := NewWriteOptions()
+	defer wo.Release()
+	ensure.Nil(t, leader.Put(wo, []byte("key1"), []byte("val1")))
+	ensure.Nil(t, leader.Put(wo, []byte("key2"), []byte("val2")))
+
+	var buf bytes.Buffer
+	next, err := SendUpdatesSince(leader, 1, &buf)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, next, uint64(3))
+	data := buf.Bytes()
+
+	// already applied updates are skipped
+	ensure.Nil(t, r.Apply(bytes.NewReader(data)))
+	ensure.Nil(t, r.Apply(bytes.NewReader(data)))
+	applied, err := r.AppliedSequence()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, applied, uint64(2))
+
+	// missing updates are reported
+	ensure.Nil(t, leader.Put(wo, []byte("key3"), []byte("val3")))
+	ensure.Nil(t, leader.Put(wo, []byte("key4"), []byte("val4")))
+	buf.Reset()
+	_, err = SendUpdatesSince(leader, 4, &buf)
+	ensure.Nil(t, err)
+	err = r.Apply(&buf)
+	ensure.DeepEqual(t, err, &TransactionLogGapError{Expected: 3, Actual: 4})
+
+	// truncated streams are reported
+	ensure.DeepEqual(t, r.Apply(bytes.NewReader(data[:len(data)-1])), io.ErrUnexpectedEOF)
+}
+
+func TestReplicatorApplyFrameTooLarge(t *testing.T) {
+	follower, r := newTestReplicator(t, "TestReplicatorApplyFrameTooLarge")
+	defer follower.Release()
+	defer r.stateCF.Release()
+	defer r.Release()
+	r.SetMaxFrameSize(16)
+
+	// the header announces a frame larger than the maximum
+	frame := []byte{0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 17}
+	ensure.NotNil(t, r.Apply(bytes.NewReader(frame)))
+}
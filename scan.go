@@ -0,0 +1,159 @@
+//go:build go1.23
+// +build go1.23
+
+package gorocksdb
+
+import (
+	"bytes"
+	"iter"
+)
+
+// A ScanOption configures the iteration of All, Range and Prefix.
+type ScanOption func(*scanConfig)
+
+type scanConfig struct {
+	reverse bool
+	err     *error
+}
+
+// ScanReverse iterates from the last key to the first.
+func ScanReverse() ScanOption {
+	return func(c *scanConfig) {
+		c.reverse = true
+	}
+}
+
+// ScanErr stores the error of the iterator in err once the iteration ends,
+// including when the loop body breaks out of it early.
+func ScanErr(err *error) ScanOption {
+	return func(c *scanConfig) {
+		c.err = err
+	}
+}
+
+// All returns an iterator over all keys and values of the database, for use
+// with range:
+//
+//	var err error
+//	for k, v := range db.All(readOpts, ScanErr(&err)) {
+//		fmt.Printf("Key: %v Value: %v\n", k, v)
+//	}
+//	if err != nil {
+//		return err
+//	}
+//
+// The underlying Iterator is released when the loop ends. The yielded keys
+// and values are only valid until the next iteration and must be copied to
+// be retained.
+func (db *DB) All(opts *ReadOptions, scanOpts ...ScanOption) iter.Seq2[[]byte, []byte] {
+	return db.scan(opts, nil, scanRange{}, scanOpts)
+}
+
+// AllCF returns an iterator over all keys and values of the column family.
+// See All.
+func (db *DB) AllCF(opts *ReadOptions, cf *CF, scanOpts ...ScanOption) iter.Seq2[[]byte, []byte] {
+	return db.scan(opts, cf, scanRange{}, scanOpts)
+}
+
+// Range returns an iterator over the keys and values of the database from
+// start up to but excluding end. A nil start or end leaves the range open
+// on that side. Keys are compared with bytes.Compare, so the database must
+// use the default comparator. See All.
+func (db *DB) Range(opts *ReadOptions, start, end []byte, scanOpts ...ScanOption) iter.Seq2[[]byte, []byte] {
+	return db.scan(opts, nil, scanRange{start: start, end: end}, scanOpts)
+}
+
+// RangeCF returns an iterator over the keys and values of the column family
+// from start up to but excluding end. See Range.
+func (db *DB) RangeCF(opts *ReadOptions, cf *CF, start, end []byte, scanOpts ...ScanOption) iter.Seq2[[]byte, []byte] {
+	return db.scan(opts, cf, scanRange{start: start, end: end}, scanOpts)
+}
+
+// Prefix returns an iterator over the keys with the prefix and their values
+// in the database. The keys with the prefix must follow each other in the
+// order of the comparator of the database, as they do with the default
+// one. See All.
+func (db *DB) Prefix(opts *ReadOptions, prefix []byte, scanOpts ...ScanOption) iter.Seq2[[]byte, []byte] {
+	return db.scan(opts, nil, scanRange{prefix: prefix}, scanOpts)
+}
+
+// PrefixCF returns an iterator over the keys with the prefix and their
+// values in the column family. See Prefix.
+func (db *DB) PrefixCF(opts *ReadOptions, cf *CF, prefix []byte, scanOpts ...ScanOption) iter.Seq2[[]byte, []byte] {
+	return db.scan(opts, cf, scanRange{prefix: prefix}, scanOpts)
+}
+
+// scan returns an iterator over the keys in r of the column family, which
+// may be nil for the default column family.
+func (db *DB) scan(opts *ReadOptions, cf *CF, r scanRange, scanOpts []ScanOption) iter.Seq2[[]byte, []byte] {
+	var c scanConfig
+	for _, o := range scanOpts {
+		o(&c)
+	}
+	return func(yield func([]byte, []byte) bool) {
+		var it *Iterator
+		if cf == nil {
+			it = db.NewIterator(opts)
+		} else {
+			it = db.NewIteratorCF(opts, cf)
+		}
+		defer it.Release()
+
+		for r.seek(it, c.reverse); it.Valid(); r.step(it, c.reverse) {
+			key := it.Key().Data()
+			if !r.contains(key) || !yield(key, it.Value().Data()) {
+				break
+			}
+		}
+		if c.err != nil {
+			*c.err = it.Err()
+		}
+	}
+}
+
+// scanRange is the set of keys iterated over by scan.
+type scanRange struct {
+	start  []byte
+	end    []byte
+	prefix []byte
+}
+
+// seek moves the iterator to the first key of the range in the order of
+// the iteration.
+func (r scanRange) seek(it *Iterator, reverse bool) {
+	switch {
+	case r.prefix != nil && reverse:
+		it.SeekToLastForPrefix(r.prefix)
+	case r.prefix != nil:
+		it.Seek(r.prefix)
+	case reverse && r.end != nil:
+		it.SeekForPrev(r.end)
+		if it.Valid() && bytes.Equal(it.Key().Data(), r.end) {
+			it.Prev()
+		}
+	case reverse:
+		it.SeekToLast()
+	case r.start != nil:
+		it.Seek(r.start)
+	default:
+		it.SeekToFirst()
+	}
+}
+
+// step moves the iterator to the next key in the order of the iteration.
+func (r scanRange) step(it *Iterator, reverse bool) {
+	if reverse {
+		it.Prev()
+	} else {
+		it.Next()
+	}
+}
+
+// contains reports whether the key is in the range.
+func (r scanRange) contains(key []byte) bool {
+	if r.prefix != nil {
+		return bytes.HasPrefix(key, r.prefix)
+	}
+	return (r.start == nil || bytes.Compare(key, r.start) >= 0) &&
+		(r.end == nil || bytes.Compare(key, r.end) < 0)
+}
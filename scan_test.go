@@ -0,0 +1,90 @@
+//go:build go1.23
+// +build go1.23
+
+package gorocksdb
+
+import (
+	"io/ioutil"
+	"iter"
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestDBScan(t *testing.T) {
+	db := newTestDB(t, "TestDBScan", nil)
+	defer db.Release()
+
+	wo := NewWriteOptions()
+	defer wo.Release()
+	for _, k := range []string{"a1", "b1", "b2", "b3", "c1"} {
+		ensure.Nil(t, db.Put(wo, []byte(k), []byte("v"+k)))
+	}
+	ro := NewReadOptions()
+	defer ro.Release()
+
+	var err error
+	ensure.DeepEqual(t, collectScan(db.All(ro, ScanErr(&err))), []string{"a1=va1", "b1=vb1", "b2=vb2", "b3=vb3", "c1=vc1"})
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, collectScan(db.All(ro, ScanReverse())), []string{"c1=vc1", "b3=vb3", "b2=vb2", "b1=vb1", "a1=va1"})
+
+	ensure.DeepEqual(t, collectScan(db.Range(ro, []byte("b1"), []byte("b3"))), []string{"b1=vb1", "b2=vb2"})
+	ensure.DeepEqual(t, collectScan(db.Range(ro, []byte("b1"), []byte("b3"), ScanReverse())), []string{"b2=vb2", "b1=vb1"})
+	ensure.DeepEqual(t, collectScan(db.Range(ro, nil, []byte("b"))), []string{"a1=va1"})
+	ensure.DeepEqual(t, collectScan(db.Range(ro, []byte("c"), nil, ScanReverse())), []string{"c1=vc1"})
+
+	ensure.DeepEqual(t, collectScan(db.Prefix(ro, []byte("b"))), []string{"b1=vb1", "b2=vb2", "b3=vb3"})
+	ensure.DeepEqual(t, collectScan(db.Prefix(ro, []byte("b"), ScanReverse())), []string{"b3=vb3", "b2=vb2", "b1=vb1"})
+	ensure.DeepEqual(t, collectScan(db.Prefix(ro, []byte("d"))), []string(nil))
+
+	// a key equal to the successor of the prefix
+	ensure.Nil(t, db.Put(wo, []byte("c"), []byte("vc")))
+	ensure.DeepEqual(t, collectScan(db.Prefix(ro, []byte("b"), ScanReverse())), []string{"b3=vb3", "b2=vb2", "b1=vb1"})
+	ensure.Nil(t, db.Delete(wo, []byte("c")))
+
+	// breaking out of the loop releases the iterator
+	var keys []string
+	for k := range db.All(ro, ScanErr(&err)) {
+		keys = append(keys, string(k))
+		if len(keys) == 2 {
+			break
+		}
+	}
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, keys, []string{"a1", "b1"})
+}
+
+func TestDBScanCF(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gorocksdb-TestDBScanCF")
+	ensure.Nil(t, err)
+
+	opts := NewOptions()
+	defer opts.Release()
+	opts.SetCreateIfMissing(true)
+	opts.SetCreateIfMissingColumnFamilies(true)
+	db, cfs, err := OpenDBCFs(opts, dir, []string{"default", "other"}, []*Options{opts, opts})
+	ensure.Nil(t, err)
+	defer db.Release()
+	defer cfs[0].Release()
+	defer cfs[1].Release()
+
+	wo := NewWriteOptions()
+	defer wo.Release()
+	ensure.Nil(t, db.Put(wo, []byte("k1"), []byte("default")))
+	ensure.Nil(t, db.PutCF(wo, cfs[1], []byte("k1"), []byte("other1")))
+	ensure.Nil(t, db.PutCF(wo, cfs[1], []byte("k2"), []byte("other2")))
+	ro := NewReadOptions()
+	defer ro.Release()
+
+	ensure.DeepEqual(t, collectScan(db.AllCF(ro, cfs[1], ScanReverse())), []string{"k2=other2", "k1=other1"})
+	ensure.DeepEqual(t, collectScan(db.RangeCF(ro, cfs[1], []byte("k2"), nil)), []string{"k2=other2"})
+	ensure.DeepEqual(t, collectScan(db.PrefixCF(ro, cfs[0], []byte("k"))), []string{"k1=default"})
+}
+
+func collectScan(seq iter.Seq2[[]byte, []byte]) []string {
+	var entries []string
+	for k, v := range seq {
+		entries = append(entries, string(k)+"="+string(v))
+	}
+	return entries
+}
@@ -1,6 +1,7 @@
 package gorocksdb
 
 // #include <stdlib.h>
+// #include "rocksdb/c.h"
 import "C"
 import "unsafe"
 
@@ -13,7 +14,11 @@ type Slice struct {
 
 // newSlice returns a slice with the given data.
 func newSlice(data *C.char, size C.size_t) *Slice {
-	return &Slice{data, size, false}
+	s := &Slice{data, size, false}
+	if data != nil {
+		trackHandle(s)
+	}
+	return s
 }
 
 // Data returns the data of the slice.
@@ -31,5 +36,54 @@ func (s *Slice) Release() {
 	if !s.freed {
 		C.free(unsafe.Pointer(s.data))
 		s.freed = true
+		untrackHandle(s)
+	}
+}
+
+// PinnableSliceHandle references a value read with GetPinned without
+// copying it, keeping the memory it lives in, such as a block of the block
+// cache, pinned until it is released.
+type PinnableSliceHandle struct {
+	c *C.rocksdb_pinnableslice_t
+
+	// suffix is the number of trailing bytes hidden from the value, used for
+	// the write timestamps of databases opened with a TTL.
+	suffix C.size_t
+}
+
+// newNativePinnableSliceHandle creates a PinnableSliceHandle object.
+func newNativePinnableSliceHandle(c *C.rocksdb_pinnableslice_t) *PinnableSliceHandle {
+	h := &PinnableSliceHandle{c: c}
+	if c != nil {
+		trackHandle(h)
+	}
+	return h
+}
+
+// Data returns the value, or nil if the key was not found. It is only valid
+// until the handle is released.
+func (h *PinnableSliceHandle) Data() []byte {
+	if h.c == nil {
+		return nil
+	}
+	var cValLen C.size_t
+	cValue := C.rocksdb_pinnableslice_value(h.c, &cValLen)
+	if cValLen >= h.suffix {
+		cValLen -= h.suffix
+	}
+	return charToByte(cValue, cValLen)
+}
+
+// Exists reports whether the key was found.
+func (h *PinnableSliceHandle) Exists() bool {
+	return h.c != nil
+}
+
+// Release unpins the value.
+func (h *PinnableSliceHandle) Release() {
+	if h.c != nil {
+		C.rocksdb_pinnableslice_destroy(h.c)
+		h.c = nil
+		untrackHandle(h)
 	}
 }
@@ -28,6 +28,43 @@ func TestSliceTransform(t *testing.T) {
 	ensure.DeepEqual(t, numFound, 2)
 }
 
+func TestSliceTransformPrefixSameAsStart(t *testing.T) {
+	db := newTestDB(t, "TestSliceTransformPrefixSameAsStart", func(opts *Options) {
+		opts.SetPrefixExtractor(NewFixedPrefixTransform(3))
+	})
+	defer db.Release()
+
+	wo := NewWriteOptions()
+	defer wo.Release()
+	ensure.Nil(t, db.Put(wo, []byte("bar1"), []byte("bar")))
+	ensure.Nil(t, db.Put(wo, []byte("foo1"), []byte("foo")))
+	ensure.Nil(t, db.Put(wo, []byte("foo2"), []byte("foo")))
+	ensure.Nil(t, db.Put(wo, []byte("goo1"), []byte("goo")))
+
+	ro := NewReadOptions()
+	defer ro.Release()
+	ro.SetPrefixSameAsStart(true)
+	iter := db.NewIterator(ro)
+	defer iter.Release()
+	numFound := 0
+	for iter.Seek([]byte("foo")); iter.Valid(); iter.Next() {
+		numFound++
+	}
+	ensure.Nil(t, iter.Err())
+	ensure.DeepEqual(t, numFound, 2)
+
+	ro.SetPrefixSameAsStart(false)
+	ro.SetTotalOrderSeek(true)
+	all := db.NewIterator(ro)
+	defer all.Release()
+	numFound = 0
+	for all.SeekToFirst(); all.Valid(); all.Next() {
+		numFound++
+	}
+	ensure.Nil(t, all.Err())
+	ensure.DeepEqual(t, numFound, 4)
+}
+
 func TestFixedPrefixTransformOpen(t *testing.T) {
 	db := newTestDB(t, "TestFixedPrefixTransformOpen", func(opts *Options) {
 		opts.SetPrefixExtractor(NewFixedPrefixTransform(3))
@@ -0,0 +1,11 @@
+#include "gorocksdb_internal.h"
+
+const rocksdb_snapshot_t* gorocksdb_transaction_get_snapshot(rocksdb_transaction_t* txn) {
+    // the C API always allocates a wrapper, even without a snapshot
+    const rocksdb_snapshot_t* snapshot = rocksdb_transaction_get_snapshot(txn);
+    if (snapshot->rep == NULL) {
+        rocksdb_free((void*)snapshot);
+        return NULL;
+    }
+    return snapshot;
+}
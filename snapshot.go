@@ -2,20 +2,54 @@ package gorocksdb
 
 // #include "rocksdb/c.h"
 import "C"
+import "unsafe"
 
 // Snapshot provides a consistent view of read operations in a DB.
 type Snapshot struct {
-	c   *C.rocksdb_snapshot_t
-	cDB *C.rocksdb_t
+	c      *C.rocksdb_snapshot_t
+	cDB    *C.rocksdb_t
+	cTxnDB *C.rocksdb_transactiondb_t
+
+	// Whether the snapshot is owned by a Transaction, which only leaves
+	// the C wrapper to free.
+	txnOwned bool
 }
 
 // newNativeSnapshot creates a Snapshot object.
 func newNativeSnapshot(c *C.rocksdb_snapshot_t, cDB *C.rocksdb_t) *Snapshot {
-	return &Snapshot{c, cDB}
+	s := &Snapshot{c: c, cDB: cDB}
+	trackHandle(s)
+	return s
+}
+
+// newNativeTransactionDBSnapshot creates a Snapshot object belonging to a
+// TransactionDB.
+func newNativeTransactionDBSnapshot(c *C.rocksdb_snapshot_t, cTxnDB *C.rocksdb_transactiondb_t) *Snapshot {
+	s := &Snapshot{c: c, cTxnDB: cTxnDB}
+	trackHandle(s)
+	return s
+}
+
+// newNativeTransactionSnapshot creates a Snapshot object owned by a
+// Transaction.
+func newNativeTransactionSnapshot(c *C.rocksdb_snapshot_t) *Snapshot {
+	s := &Snapshot{c: c, txnOwned: true}
+	trackHandle(s)
+	return s
 }
 
 // Release removes the snapshot from the database's list of snapshots.
+// Snapshots owned by a Transaction are released with the transaction, so
+// Release only frees the object returned by Transaction.Snapshot.
 func (s *Snapshot) Release() {
-	C.rocksdb_release_snapshot(s.cDB, s.c)
-	s.c, s.cDB = nil, nil
+	switch {
+	case s.txnOwned:
+		C.rocksdb_free(unsafe.Pointer(s.c))
+	case s.cTxnDB != nil:
+		C.rocksdb_transactiondb_release_snapshot(s.cTxnDB, s.c)
+	case s.cDB != nil:
+		C.rocksdb_release_snapshot(s.cDB, s.c)
+	}
+	s.c, s.cDB, s.cTxnDB, s.txnOwned = nil, nil, nil, false
+	untrackHandle(s)
 }
@@ -0,0 +1,90 @@
+package gorocksdb
+
+// #include <stdlib.h>
+// #include "rocksdb/c.h"
+import "C"
+import "unsafe"
+
+// SstFileWriter builds SST files outside of a database which can later be
+// added to one with DB.IngestExternalFile. Keys must be added in the order
+// defined by the comparator of the Options the writer was created with,
+// and the same comparator must be used by the database ingesting the file.
+type SstFileWriter struct {
+	c *C.rocksdb_sstfilewriter_t
+
+	// Hold references for GC.
+	opts *Options
+}
+
+// NewSstFileWriter creates a SstFileWriter object. The table format,
+// comparator and compression of the written files are taken from opts.
+func NewSstFileWriter(envOpts *EnvOptions, opts *Options) *SstFileWriter {
+	c := C.rocksdb_sstfilewriter_create(envOpts.c, opts.c)
+	return &SstFileWriter{c: c, opts: opts}
+}
+
+// Open prepares the writer to write to the file at path.
+func (w *SstFileWriter) Open(path string) error {
+	var cErr *C.char
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+	C.rocksdb_sstfilewriter_open(w.c, cPath, &cErr)
+	return convertErr(cErr)
+}
+
+// Put adds a key-value pair to the file. The key must be greater than any
+// key previously added.
+func (w *SstFileWriter) Put(key, value []byte) error {
+	var (
+		cErr   *C.char
+		cKey   = byteToChar(key)
+		cValue = byteToChar(value)
+	)
+	C.rocksdb_sstfilewriter_put(w.c, cKey, C.size_t(len(key)), cValue, C.size_t(len(value)), &cErr)
+	return convertErr(cErr)
+}
+
+// Merge adds a merge operand for the key to the file. The key must be
+// greater than any key previously added.
+func (w *SstFileWriter) Merge(key, value []byte) error {
+	var (
+		cErr   *C.char
+		cKey   = byteToChar(key)
+		cValue = byteToChar(value)
+	)
+	C.rocksdb_sstfilewriter_merge(w.c, cKey, C.size_t(len(key)), cValue, C.size_t(len(value)), &cErr)
+	return convertErr(cErr)
+}
+
+// Delete adds a deletion of the key to the file. The key must be greater
+// than any key previously added.
+func (w *SstFileWriter) Delete(key []byte) error {
+	var (
+		cErr *C.char
+		cKey = byteToChar(key)
+	)
+	C.rocksdb_sstfilewriter_delete(w.c, cKey, C.size_t(len(key)), &cErr)
+	return convertErr(cErr)
+}
+
+// Finish finalizes writing to the file. The file must not be written to
+// after Finish.
+func (w *SstFileWriter) Finish() error {
+	var cErr *C.char
+	C.rocksdb_sstfilewriter_finish(w.c, &cErr)
+	return convertErr(cErr)
+}
+
+// FileSize returns the current size of the file being written.
+func (w *SstFileWriter) FileSize() uint64 {
+	var cSize C.uint64_t
+	C.rocksdb_sstfilewriter_file_size(w.c, &cSize)
+	return uint64(cSize)
+}
+
+// Release deallocates the SstFileWriter object.
+func (w *SstFileWriter) Release() {
+	C.rocksdb_sstfilewriter_destroy(w.c)
+	w.c = nil
+	w.opts = nil
+}
@@ -0,0 +1,75 @@
+package gorocksdb
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestSstFileWriterIngest(t *testing.T) {
+	db := newTestDB(t, "TestSstFileWriterIngest", nil)
+	defer db.Release()
+
+	dir, err := ioutil.TempDir("", "gorocksdb-TestSstFileWriterIngest-sst")
+	ensure.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "1.sst")
+
+	var (
+		givenKeys = [][]byte{[]byte("key1"), []byte("key2"), []byte("key3")}
+		givenVal  = []byte("val")
+		wo        = NewWriteOptions()
+		ro        = NewReadOptions()
+	)
+	defer wo.Release()
+	defer ro.Release()
+	ensure.Nil(t, db.Put(wo, givenKeys[2], givenVal))
+
+	// write the sst file, the deletion shadows the existing key
+	envOpts := NewEnvOptions()
+	defer envOpts.Release()
+	opts := NewOptions()
+	defer opts.Release()
+	writer := NewSstFileWriter(envOpts, opts)
+	defer writer.Release()
+	ensure.Nil(t, writer.Open(path))
+	ensure.Nil(t, writer.Put(givenKeys[0], givenVal))
+	ensure.Nil(t, writer.Put(givenKeys[1], givenVal))
+	ensure.Nil(t, writer.Delete(givenKeys[2]))
+	ensure.Nil(t, writer.Finish())
+	ensure.True(t, writer.FileSize() > 0)
+
+	ingestOpts := NewIngestExternalFileOptions()
+	defer ingestOpts.Release()
+	ensure.NotNil(t, db.IngestExternalFile(nil, ingestOpts))
+	ensure.Nil(t, db.IngestExternalFile([]string{path}, ingestOpts))
+
+	for _, k := range givenKeys[:2] {
+		v, err := db.Get(ro, k)
+		ensure.Nil(t, err)
+		ensure.DeepEqual(t, v.Data(), givenVal)
+		v.Release()
+	}
+	v, err := db.Get(ro, givenKeys[2])
+	ensure.Nil(t, err)
+	ensure.True(t, v.Data() == nil)
+}
+
+func TestSstFileWriterOutOfOrder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gorocksdb-TestSstFileWriterOutOfOrder")
+	ensure.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	envOpts := NewEnvOptions()
+	defer envOpts.Release()
+	opts := NewOptions()
+	defer opts.Release()
+	writer := NewSstFileWriter(envOpts, opts)
+	defer writer.Release()
+	ensure.Nil(t, writer.Open(filepath.Join(dir, "1.sst")))
+	ensure.Nil(t, writer.Put([]byte("key2"), []byte("val")))
+	ensure.NotNil(t, writer.Put([]byte("key1"), []byte("val")))
+}
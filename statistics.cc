@@ -0,0 +1,84 @@
+#include "rocksdb/statistics.h"
+#include "gorocksdb_internal.h"
+
+using rocksdb::HistogramData;
+using rocksdb::HistogramsNameMap;
+using rocksdb::Statistics;
+using rocksdb::TickersNameMap;
+
+// Tickers and histograms are identified by their index in the name maps of
+// RocksDB, so Go does not depend on the values of the enums.
+
+size_t gorocksdb_statistics_num_tickers(void) {
+    return TickersNameMap.size();
+}
+
+const char* gorocksdb_statistics_ticker_name(size_t i) {
+    return TickersNameMap[i].second.c_str();
+}
+
+size_t gorocksdb_statistics_num_histograms(void) {
+    return HistogramsNameMap.size();
+}
+
+const char* gorocksdb_statistics_histogram_name(size_t i) {
+    return HistogramsNameMap[i].second.c_str();
+}
+
+unsigned char gorocksdb_options_statistics_get_tickers(rocksdb_options_t* opts, uint64_t* values) {
+    Statistics* stats = opts->rep.statistics.get();
+    if (stats == NULL) {
+        return 0;
+    }
+    for (size_t i = 0; i < TickersNameMap.size(); i++) {
+        values[i] = stats->getTickerCount(TickersNameMap[i].first);
+    }
+    return 1;
+}
+
+unsigned char gorocksdb_options_statistics_get_ticker(rocksdb_options_t* opts, size_t i, uint64_t* value) {
+    Statistics* stats = opts->rep.statistics.get();
+    if (stats == NULL) {
+        return 0;
+    }
+    *value = stats->getTickerCount(TickersNameMap[i].first);
+    return 1;
+}
+
+static void gorocksdb_histogram_data(Statistics* stats, size_t i, gorocksdb_histogramdata_t* data) {
+    HistogramData h;
+    stats->histogramData(HistogramsNameMap[i].first, &h);
+    data->median = h.median;
+    data->p95 = h.percentile95;
+    data->p99 = h.percentile99;
+    data->max = h.max;
+    data->count = h.count;
+    data->sum = h.sum;
+}
+
+unsigned char gorocksdb_options_statistics_get_histograms(rocksdb_options_t* opts, gorocksdb_histogramdata_t* data) {
+    Statistics* stats = opts->rep.statistics.get();
+    if (stats == NULL) {
+        return 0;
+    }
+    for (size_t i = 0; i < HistogramsNameMap.size(); i++) {
+        gorocksdb_histogram_data(stats, i, &data[i]);
+    }
+    return 1;
+}
+
+unsigned char gorocksdb_options_statistics_get_histogram(rocksdb_options_t* opts, size_t i, gorocksdb_histogramdata_t* data) {
+    Statistics* stats = opts->rep.statistics.get();
+    if (stats == NULL) {
+        return 0;
+    }
+    gorocksdb_histogram_data(stats, i, data);
+    return 1;
+}
+
+void gorocksdb_options_statistics_reset(rocksdb_options_t* opts, char** errptr) {
+    Statistics* stats = opts->rep.statistics.get();
+    if (stats != NULL) {
+        gorocksdb_save_error(errptr, stats->Reset());
+    }
+}
@@ -0,0 +1,216 @@
+package gorocksdb
+
+// #include <stdlib.h>
+// #include "rocksdb/c.h"
+// #include "gorocksdb.h"
+import "C"
+import (
+	"sync"
+	"unsafe"
+)
+
+// TickerType is the name of a statistics counter.
+type TickerType string
+
+// Commonly used tickers. Any other ticker reported by RocksDB can be read
+// by converting its name to a TickerType.
+const (
+	TickerBlockCacheMiss      = TickerType("rocksdb.block.cache.miss")
+	TickerBlockCacheHit       = TickerType("rocksdb.block.cache.hit")
+	TickerBlockCacheAdd       = TickerType("rocksdb.block.cache.add")
+	TickerBloomFilterUseful   = TickerType("rocksdb.bloom.filter.useful")
+	TickerMemtableHit         = TickerType("rocksdb.memtable.hit")
+	TickerMemtableMiss        = TickerType("rocksdb.memtable.miss")
+	TickerNumberKeysWritten   = TickerType("rocksdb.number.keys.written")
+	TickerNumberKeysRead      = TickerType("rocksdb.number.keys.read")
+	TickerBytesWritten        = TickerType("rocksdb.bytes.written")
+	TickerBytesRead           = TickerType("rocksdb.bytes.read")
+	TickerCompactReadBytes    = TickerType("rocksdb.compact.read.bytes")
+	TickerCompactWriteBytes   = TickerType("rocksdb.compact.write.bytes")
+	TickerFlushWriteBytes     = TickerType("rocksdb.flush.write.bytes")
+	TickerWALFileBytes        = TickerType("rocksdb.wal.bytes")
+	TickerWALFileSynced       = TickerType("rocksdb.wal.synced")
+	TickerStallMicros         = TickerType("rocksdb.stall.micros")
+	TickerNoFileOpens         = TickerType("rocksdb.no.file.opens")
+	TickerNumberDBSeek        = TickerType("rocksdb.number.db.seek")
+	TickerNumberMultiGetCalls = TickerType("rocksdb.number.multiget.get")
+)
+
+// HistogramType is the name of a statistics histogram.
+type HistogramType string
+
+// Commonly used histograms. Any other histogram reported by RocksDB can be
+// read by converting its name to a HistogramType.
+const (
+	HistogramDBGet          = HistogramType("rocksdb.db.get.micros")
+	HistogramDBWrite        = HistogramType("rocksdb.db.write.micros")
+	HistogramDBMultiGet     = HistogramType("rocksdb.db.multiget.micros")
+	HistogramDBSeek         = HistogramType("rocksdb.db.seek.micros")
+	HistogramCompactionTime = HistogramType("rocksdb.compaction.times.micros")
+	HistogramFlushTime      = HistogramType("rocksdb.db.flush.micros")
+	HistogramWALFileSync    = HistogramType("rocksdb.wal.file.sync.micros")
+	HistogramWriteStall     = HistogramType("rocksdb.db.write.stall")
+)
+
+// HistogramData is a summary of the values recorded by a histogram.
+type HistogramData struct {
+	Median float64
+	P95    float64
+	P99    float64
+	Max    float64
+	Count  uint64
+	Sum    uint64
+}
+
+// Average returns the average of the recorded values.
+func (h HistogramData) Average() float64 {
+	if h.Count == 0 {
+		return 0
+	}
+	return float64(h.Sum) / float64(h.Count)
+}
+
+// Statistics reads the statistics collected by a database opened with
+// Options on which EnableStatistics was called.
+type Statistics struct {
+	opts *Options
+}
+
+// Statistics returns the statistics of the databases opened with these
+// options. The returned object is only valid as long as the options.
+func (o *Options) Statistics() *Statistics {
+	if o.stats == nil {
+		o.stats = &Statistics{opts: o}
+	}
+	return o.stats
+}
+
+// String returns the statistics in the human readable format of RocksDB.
+// It returns an empty string if statistics are not enabled.
+func (s *Statistics) String() string {
+	cValue := C.rocksdb_options_statistics_get_string(s.opts.c)
+	if cValue == nil {
+		return ""
+	}
+	defer C.free(unsafe.Pointer(cValue))
+	return C.GoString(cValue)
+}
+
+// Ticker returns the value of a ticker, or 0 if it is unknown or statistics
+// are not enabled.
+func (s *Statistics) Ticker(ticker TickerType) uint64 {
+	i, ok := statisticsNames().tickerIndex[ticker]
+	if !ok {
+		return 0
+	}
+	var cValue C.uint64_t
+	if C.gorocksdb_options_statistics_get_ticker(s.opts.c, C.size_t(i), &cValue) == 0 {
+		return 0
+	}
+	return uint64(cValue)
+}
+
+// Tickers returns the values of all tickers, or nil if statistics are not
+// enabled.
+func (s *Statistics) Tickers() map[TickerType]uint64 {
+	names := statisticsNames()
+	if len(names.tickers) == 0 {
+		return nil
+	}
+	cValues := make([]C.uint64_t, len(names.tickers))
+	if C.gorocksdb_options_statistics_get_tickers(s.opts.c, &cValues[0]) == 0 {
+		return nil
+	}
+	tickers := make(map[TickerType]uint64, len(names.tickers))
+	for i, name := range names.tickers {
+		tickers[name] = uint64(cValues[i])
+	}
+	return tickers
+}
+
+// Histogram returns the summary of a histogram, or an empty summary if it
+// is unknown or statistics are not enabled.
+func (s *Statistics) Histogram(histogram HistogramType) HistogramData {
+	i, ok := statisticsNames().histogramIndex[histogram]
+	if !ok {
+		return HistogramData{}
+	}
+	var cData C.gorocksdb_histogramdata_t
+	if C.gorocksdb_options_statistics_get_histogram(s.opts.c, C.size_t(i), &cData) == 0 {
+		return HistogramData{}
+	}
+	return newHistogramData(&cData)
+}
+
+// Histograms returns the summaries of all histograms, or nil if statistics
+// are not enabled.
+func (s *Statistics) Histograms() map[HistogramType]HistogramData {
+	names := statisticsNames()
+	if len(names.histograms) == 0 {
+		return nil
+	}
+	cData := make([]C.gorocksdb_histogramdata_t, len(names.histograms))
+	if C.gorocksdb_options_statistics_get_histograms(s.opts.c, &cData[0]) == 0 {
+		return nil
+	}
+	histograms := make(map[HistogramType]HistogramData, len(names.histograms))
+	for i, name := range names.histograms {
+		histograms[name] = newHistogramData(&cData[i])
+	}
+	return histograms
+}
+
+// Reset resets all tickers and histograms to zero.
+func (s *Statistics) Reset() error {
+	var cErr *C.char
+	C.gorocksdb_options_statistics_reset(s.opts.c, &cErr)
+	return convertErr(cErr)
+}
+
+func newHistogramData(cData *C.gorocksdb_histogramdata_t) HistogramData {
+	return HistogramData{
+		Median: float64(cData.median),
+		P95:    float64(cData.p95),
+		P99:    float64(cData.p99),
+		Max:    float64(cData.max),
+		Count:  uint64(cData.count),
+		Sum:    uint64(cData.sum),
+	}
+}
+
+// statisticsNameTable maps the names of the tickers and histograms known to
+// RocksDB to their index in its name maps.
+type statisticsNameTable struct {
+	tickers        []TickerType
+	tickerIndex    map[TickerType]int
+	histograms     []HistogramType
+	histogramIndex map[HistogramType]int
+}
+
+var (
+	statisticsNamesOnce  sync.Once
+	statisticsNamesTable statisticsNameTable
+)
+
+func statisticsNames() *statisticsNameTable {
+	statisticsNamesOnce.Do(func() {
+		t := &statisticsNamesTable
+		n := int(C.gorocksdb_statistics_num_tickers())
+		t.tickers = make([]TickerType, n)
+		t.tickerIndex = make(map[TickerType]int, n)
+		for i := 0; i < n; i++ {
+			name := TickerType(C.GoString(C.gorocksdb_statistics_ticker_name(C.size_t(i))))
+			t.tickers[i] = name
+			t.tickerIndex[name] = i
+		}
+		n = int(C.gorocksdb_statistics_num_histograms())
+		t.histograms = make([]HistogramType, n)
+		t.histogramIndex = make(map[HistogramType]int, n)
+		for i := 0; i < n; i++ {
+			name := HistogramType(C.GoString(C.gorocksdb_statistics_histogram_name(C.size_t(i))))
+			t.histograms[i] = name
+			t.histogramIndex[name] = i
+		}
+	})
+	return &statisticsNamesTable
+}
@@ -0,0 +1,44 @@
+package gorocksdb
+
+import (
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestHistogramDataAverage(t *testing.T) {
+	ensure.DeepEqual(t, HistogramData{Count: 12, Sum: 30}.Average(), 2.5)
+	ensure.DeepEqual(t, HistogramData{}.Average(), float64(0))
+}
+
+func TestStatistics(t *testing.T) {
+	var stats *Statistics
+	db := newTestDB(t, "TestStatistics", func(opts *Options) {
+		opts.EnableStatistics()
+		stats = opts.Statistics()
+	})
+	defer db.Release()
+
+	wo := NewWriteOptions()
+	defer wo.Release()
+	ro := NewReadOptions()
+	defer ro.Release()
+	ensure.Nil(t, db.Put(wo, []byte("key1"), []byte("val1")))
+	ensure.Nil(t, db.Put(wo, []byte("key2"), []byte("val2")))
+	v, err := db.Get(ro, []byte("key1"))
+	ensure.Nil(t, err)
+	v.Release()
+
+	ensure.DeepEqual(t, stats.Ticker(TickerNumberKeysWritten), uint64(2))
+	ensure.DeepEqual(t, stats.Ticker(TickerNumberKeysRead), uint64(1))
+	ensure.True(t, stats.Ticker(TickerBytesWritten) > 0)
+	ensure.DeepEqual(t, stats.Histogram(HistogramDBGet).Count, uint64(1))
+	ensure.DeepEqual(t, stats.Histogram(HistogramDBWrite).Count, uint64(2))
+
+	ensure.Nil(t, stats.Reset())
+	ensure.DeepEqual(t, stats.Ticker(TickerNumberKeysWritten), uint64(0))
+	ensure.DeepEqual(t, stats.Histogram(HistogramDBWrite).Count, uint64(0))
+	ensure.Nil(t, db.Put(wo, []byte("key3"), []byte("val3")))
+	ensure.DeepEqual(t, stats.Ticker(TickerNumberKeysWritten), uint64(1))
+	ensure.DeepEqual(t, stats.Histograms()[HistogramDBWrite].Count, uint64(1))
+}
@@ -0,0 +1,273 @@
+package gorocksdb
+
+// #include <stdlib.h>
+// #include "rocksdb/c.h"
+// #include "gorocksdb.h"
+import "C"
+import (
+	"errors"
+	"strings"
+)
+
+// TransactionConflictError is returned by transactional operations which
+// failed because of a conflict with another transaction or writer, such as
+// a write-write conflict detected at commit, a lock wait that timed out or
+// an expired transaction. The operation may succeed if retried.
+type TransactionConflictError struct {
+	msg string
+}
+
+// Error returns the message reported by RocksDB.
+func (e *TransactionConflictError) Error() string {
+	return e.msg
+}
+
+// IsTransactionConflict reports whether err is a TransactionConflictError.
+func IsTransactionConflict(err error) bool {
+	var conflict *TransactionConflictError
+	return errors.As(err, &conflict)
+}
+
+// Status message prefixes RocksDB uses for the Busy, TimedOut, Expired and
+// TryAgain codes, all of which indicate a transaction conflict.
+var transactionConflictPrefixes = []string{
+	"Resource busy",
+	"Operation timed out",
+	"Operation expired",
+	"Operation failed. Try again.",
+}
+
+// convertTxnErr converts a cErr to a go error like convertErr, but returns
+// a TransactionConflictError for errors caused by transaction conflicts.
+func convertTxnErr(cErr *C.char) error {
+	err := convertErr(cErr)
+	if err == nil {
+		return nil
+	}
+	msg := err.Error()
+	for _, prefix := range transactionConflictPrefixes {
+		if strings.HasPrefix(msg, prefix) {
+			return &TransactionConflictError{msg: msg}
+		}
+	}
+	return err
+}
+
+// Transaction is a set of reads and writes which are applied atomically on
+// Commit, created by TransactionDB.Begin or OptimisticTransactionDB.Begin.
+//
+// For example:
+//
+//	txn := db.Begin(wo, txnOpts, nil)
+//	defer txn.Release()
+//
+//	v, err := txn.GetForUpdate(ro, key)
+//	if err != nil {
+//	    return err
+//	}
+//	defer v.Release()
+//	if err := txn.Put(key, next(v.Data())); err != nil {
+//	    return err
+//	}
+//	return txn.Commit()
+type Transaction struct {
+	c *C.rocksdb_transaction_t
+}
+
+// newNativeTransaction creates a Transaction object.
+func newNativeTransaction(c *C.rocksdb_transaction_t) *Transaction {
+	t := &Transaction{c}
+	trackHandle(t)
+	return t
+}
+
+// Get returns the data associated with the key, including any uncommitted
+// writes made by this transaction.
+func (t *Transaction) Get(opts *ReadOptions, key []byte) (*Slice, error) {
+	var (
+		cErr    *C.char
+		cValLen C.size_t
+		cKey    = byteToChar(key)
+	)
+	cValue := C.rocksdb_transaction_get(t.c, opts.c, cKey, C.size_t(len(key)), &cValLen, &cErr)
+	if cErr != nil {
+		return nil, convertTxnErr(cErr)
+	}
+	return newSlice(cValue, cValLen), nil
+}
+
+// GetCF returns the data associated with the key in the column family,
+// including any uncommitted writes made by this transaction.
+func (t *Transaction) GetCF(opts *ReadOptions, cf *CF, key []byte) (*Slice, error) {
+	var (
+		cErr    *C.char
+		cValLen C.size_t
+		cKey    = byteToChar(key)
+	)
+	cValue := C.rocksdb_transaction_get_cf(t.c, opts.c, cf.c, cKey, C.size_t(len(key)), &cValLen, &cErr)
+	if cErr != nil {
+		return nil, convertTxnErr(cErr)
+	}
+	return newSlice(cValue, cValLen), nil
+}
+
+// GetForUpdate returns the data associated with the key like Get, and
+// additionally tracks the key so that the transaction can only commit if no
+// one else has written it since it was read. On a TransactionDB the key is
+// locked exclusively until the transaction ends.
+func (t *Transaction) GetForUpdate(opts *ReadOptions, key []byte) (*Slice, error) {
+	var (
+		cErr    *C.char
+		cValLen C.size_t
+		cKey    = byteToChar(key)
+	)
+	cValue := C.rocksdb_transaction_get_for_update(t.c, opts.c, cKey, C.size_t(len(key)), &cValLen, boolToChar(true), &cErr)
+	if cErr != nil {
+		return nil, convertTxnErr(cErr)
+	}
+	return newSlice(cValue, cValLen), nil
+}
+
+// GetForUpdateCF returns the data associated with the key in the column
+// family like GetForUpdate.
+func (t *Transaction) GetForUpdateCF(opts *ReadOptions, cf *CF, key []byte) (*Slice, error) {
+	var (
+		cErr    *C.char
+		cValLen C.size_t
+		cKey    = byteToChar(key)
+	)
+	cValue := C.rocksdb_transaction_get_for_update_cf(t.c, opts.c, cf.c, cKey, C.size_t(len(key)), &cValLen, boolToChar(true), &cErr)
+	if cErr != nil {
+		return nil, convertTxnErr(cErr)
+	}
+	return newSlice(cValue, cValLen), nil
+}
+
+// Put writes data associated with a key in the transaction.
+func (t *Transaction) Put(key, value []byte) error {
+	var (
+		cErr   *C.char
+		cKey   = byteToChar(key)
+		cValue = byteToChar(value)
+	)
+	C.rocksdb_transaction_put(t.c, cKey, C.size_t(len(key)), cValue, C.size_t(len(value)), &cErr)
+	return convertTxnErr(cErr)
+}
+
+// PutCF writes data associated with a key in the column family in the
+// transaction.
+func (t *Transaction) PutCF(cf *CF, key, value []byte) error {
+	var (
+		cErr   *C.char
+		cKey   = byteToChar(key)
+		cValue = byteToChar(value)
+	)
+	C.rocksdb_transaction_put_cf(t.c, cf.c, cKey, C.size_t(len(key)), cValue, C.size_t(len(value)), &cErr)
+	return convertTxnErr(cErr)
+}
+
+// Delete removes the data associated with the key in the transaction.
+func (t *Transaction) Delete(key []byte) error {
+	var (
+		cErr *C.char
+		cKey = byteToChar(key)
+	)
+	C.rocksdb_transaction_delete(t.c, cKey, C.size_t(len(key)), &cErr)
+	return convertTxnErr(cErr)
+}
+
+// DeleteCF removes the data associated with the key in the column family in
+// the transaction.
+func (t *Transaction) DeleteCF(cf *CF, key []byte) error {
+	var (
+		cErr *C.char
+		cKey = byteToChar(key)
+	)
+	C.rocksdb_transaction_delete_cf(t.c, cf.c, cKey, C.size_t(len(key)), &cErr)
+	return convertTxnErr(cErr)
+}
+
+// Merge merges the data associated with the key with the actual data in the
+// transaction.
+func (t *Transaction) Merge(key, value []byte) error {
+	var (
+		cErr   *C.char
+		cKey   = byteToChar(key)
+		cValue = byteToChar(value)
+	)
+	C.rocksdb_transaction_merge(t.c, cKey, C.size_t(len(key)), cValue, C.size_t(len(value)), &cErr)
+	return convertTxnErr(cErr)
+}
+
+// MergeCF merges the data associated with the key with the actual data in
+// the column family in the transaction.
+func (t *Transaction) MergeCF(cf *CF, key, value []byte) error {
+	var (
+		cErr   *C.char
+		cKey   = byteToChar(key)
+		cValue = byteToChar(value)
+	)
+	C.rocksdb_transaction_merge_cf(t.c, cf.c, cKey, C.size_t(len(key)), cValue, C.size_t(len(value)), &cErr)
+	return convertTxnErr(cErr)
+}
+
+// NewIterator returns an Iterator over the database merged with the
+// uncommitted writes of the transaction.
+func (t *Transaction) NewIterator(opts *ReadOptions) *Iterator {
+	return newNativeIterator(C.rocksdb_transaction_create_iterator(t.c, opts.c))
+}
+
+// NewIteratorCF returns an Iterator over the column family merged with the
+// uncommitted writes of the transaction.
+func (t *Transaction) NewIteratorCF(opts *ReadOptions, cf *CF) *Iterator {
+	return newNativeIterator(C.rocksdb_transaction_create_iterator_cf(t.c, opts.c, cf.c))
+}
+
+// Snapshot returns the snapshot taken when the transaction began, or nil if
+// no snapshot was requested. The snapshot itself is owned by the
+// transaction, but the returned object must still be released.
+func (t *Transaction) Snapshot() *Snapshot {
+	cSnap := C.gorocksdb_transaction_get_snapshot(t.c)
+	if cSnap == nil {
+		return nil
+	}
+	return newNativeTransactionSnapshot(cSnap)
+}
+
+// SetSavePoint records the state of the transaction so that later writes
+// can be undone with RollbackToSavePoint. Save points nest.
+func (t *Transaction) SetSavePoint() {
+	C.rocksdb_transaction_set_savepoint(t.c)
+}
+
+// RollbackToSavePoint undoes all writes made since the most recent call to
+// SetSavePoint and removes that save point.
+func (t *Transaction) RollbackToSavePoint() error {
+	var cErr *C.char
+	C.rocksdb_transaction_rollback_to_savepoint(t.c, &cErr)
+	return convertErr(cErr)
+}
+
+// Commit writes all changes of the transaction to the database. A
+// TransactionConflictError is returned if the transaction could not be
+// committed because of a conflict.
+func (t *Transaction) Commit() error {
+	var cErr *C.char
+	C.rocksdb_transaction_commit(t.c, &cErr)
+	return convertTxnErr(cErr)
+}
+
+// Rollback discards all changes of the transaction.
+func (t *Transaction) Rollback() error {
+	var cErr *C.char
+	C.rocksdb_transaction_rollback(t.c, &cErr)
+	return convertErr(cErr)
+}
+
+// Release deallocates the Transaction object. A transaction which was
+// neither committed nor rolled back is rolled back.
+func (t *Transaction) Release() {
+	C.rocksdb_transaction_destroy(t.c)
+	t.c = nil
+	untrackHandle(t)
+}
@@ -0,0 +1,145 @@
+package gorocksdb
+
+// #include "rocksdb/c.h"
+import "C"
+import "fmt"
+
+// TransactionLogGapError is returned by TransactionLogIterator.Err if
+// updates are missing from the write ahead log, usually because the log
+// files holding them were deleted before they were read. See
+// Options.SetWALTtlSeconds and Options.SetWalSizeLimitMb to retain them
+// longer.
+type TransactionLogGapError struct {
+	// Expected is the sequence number of the next update the iterator
+	// expected to read.
+	Expected uint64
+	// Actual is the sequence number of the update found instead.
+	Actual uint64
+}
+
+func (e *TransactionLogGapError) Error() string {
+	return fmt.Sprintf("gorocksdb: transaction log gap, expected sequence %d but found %d", e.Expected, e.Actual)
+}
+
+// TransactionLogIterator iterates over the write batches committed to a
+// database in sequence number order, created by DB.GetUpdatesSince.
+//
+// For example:
+//
+//	it, err := db.GetUpdatesSince(seq)
+//	if err != nil {
+//	    return err
+//	}
+//	defer it.Release()
+//
+//	for ; it.Valid(); it.Next() {
+//	    batch, seq := it.GetBatch()
+//	    ...
+//	    batch.Release()
+//	}
+//
+//	if err := it.Err(); err != nil {
+//	    return err
+//	}
+type TransactionLogIterator struct {
+	c *C.rocksdb_wal_iterator_t
+
+	// The sequence number the next batch must not start after, or 0 if any
+	// is expected.
+	next uint64
+
+	// The current batch, fetched by Valid until handed out by GetBatch.
+	batch   *WriteBatch
+	seq     uint64
+	fetched bool
+	err     error
+}
+
+// GetLatestSequenceNumber returns the sequence number of the most recent
+// update committed to the database.
+func (db *DB) GetLatestSequenceNumber() uint64 {
+	return uint64(C.rocksdb_get_latest_sequence_number(db.c))
+}
+
+// GetUpdatesSince returns an iterator over all updates committed to the
+// database starting with the write batch containing sequence number seq.
+// A seq of 0 starts with the oldest update still available.
+//
+// Updates are read from the write ahead log, so only updates whose log
+// files have not been deleted yet are available. Archived log files are
+// kept as configured with Options.SetWALTtlSeconds and
+// Options.SetWalSizeLimitMb; if neither is set they are deleted as soon as
+// they are no longer needed for recovery.
+func (db *DB) GetUpdatesSince(seq uint64) (*TransactionLogIterator, error) {
+	var cErr *C.char
+	cIter := C.rocksdb_get_updates_since(db.c, C.uint64_t(seq), nil, &cErr)
+	if cErr != nil {
+		return nil, convertErr(cErr)
+	}
+	return &TransactionLogIterator{c: cIter, next: seq}, nil
+}
+
+// Valid returns false when there are no more updates to read, or when the
+// iteration failed, in which case Err returns the reason.
+func (it *TransactionLogIterator) Valid() bool {
+	if it.err != nil || C.rocksdb_wal_iter_valid(it.c) == 0 {
+		return false
+	}
+	if !it.fetched {
+		var cSeq C.uint64_t
+		it.batch = newNativeWriteBatch(C.rocksdb_wal_iter_get_batch(it.c, &cSeq))
+		it.seq = uint64(cSeq)
+		it.fetched = true
+		if it.next != 0 && it.seq > it.next {
+			it.err = &TransactionLogGapError{Expected: it.next, Actual: it.seq}
+			return false
+		}
+		it.next = it.seq + uint64(it.batch.Count())
+	}
+	return true
+}
+
+// Next moves the iterator to the next write batch.
+func (it *TransactionLogIterator) Next() {
+	it.releaseBatch()
+	C.rocksdb_wal_iter_next(it.c)
+}
+
+// GetBatch returns the current write batch and the sequence number of its
+// first update. The batch can be decoded with WriteBatch.NewIterator and
+// must be released by the caller.
+func (it *TransactionLogIterator) GetBatch() (*WriteBatch, uint64) {
+	if !it.Valid() {
+		return nil, 0
+	}
+	batch := it.batch
+	it.batch = nil
+	return batch, it.seq
+}
+
+// Err returns nil if no errors happened during iteration, or the actual
+// error otherwise.
+func (it *TransactionLogIterator) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+	var cErr *C.char
+	C.rocksdb_wal_iter_status(it.c, &cErr)
+	return convertErr(cErr)
+}
+
+// Release closes the iterator.
+func (it *TransactionLogIterator) Release() {
+	it.releaseBatch()
+	C.rocksdb_wal_iter_destroy(it.c)
+	it.c = nil
+}
+
+// releaseBatch releases the current batch unless it was handed out.
+func (it *TransactionLogIterator) releaseBatch() {
+	if it.batch != nil {
+		it.batch.Release()
+		it.batch = nil
+	}
+	it.fetched = false
+}
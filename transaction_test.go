@@ -0,0 +1,198 @@
+package gorocksdb
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestTransactionCommitRollback(t *testing.T) {
+	db := newTestTransactionDB(t, "TestTransactionCommitRollback")
+	defer db.Release()
+
+	var (
+		givenKey  = []byte("hello")
+		givenVal1 = []byte("world1")
+		givenVal2 = []byte("world2")
+		wo        = NewWriteOptions()
+		ro        = NewReadOptions()
+		to        = NewTransactionOptions()
+	)
+	defer wo.Release()
+	defer ro.Release()
+	defer to.Release()
+	ensure.Nil(t, db.Put(wo, givenKey, givenVal1))
+
+	// uncommitted writes are only visible inside the transaction
+	txn := db.Begin(wo, to, nil)
+	defer txn.Release()
+	ensure.Nil(t, txn.Put(givenKey, givenVal2))
+	v1, err := txn.Get(ro, givenKey)
+	defer v1.Release()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v1.Data(), givenVal2)
+	v2, err := db.Get(ro, givenKey)
+	defer v2.Release()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v2.Data(), givenVal1)
+
+	// rollback discards the write
+	ensure.Nil(t, txn.Rollback())
+	v3, err := db.Get(ro, givenKey)
+	defer v3.Release()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v3.Data(), givenVal1)
+
+	// reuse the transaction and commit
+	txn = db.Begin(wo, to, txn)
+	ensure.Nil(t, txn.Delete(givenKey))
+	ensure.Nil(t, txn.Commit())
+	v4, err := db.Get(ro, givenKey)
+	ensure.Nil(t, err)
+	ensure.True(t, v4.Data() == nil)
+}
+
+func TestTransactionSavePoint(t *testing.T) {
+	db := newTestTransactionDB(t, "TestTransactionSavePoint")
+	defer db.Release()
+
+	var (
+		givenKey1 = []byte("key1")
+		givenKey2 = []byte("key2")
+		givenVal  = []byte("val")
+		wo        = NewWriteOptions()
+		ro        = NewReadOptions()
+		to        = NewTransactionOptions()
+	)
+	defer wo.Release()
+	defer ro.Release()
+	defer to.Release()
+	txn := db.Begin(wo, to, nil)
+	defer txn.Release()
+	ensure.Nil(t, txn.Put(givenKey1, givenVal))
+	txn.SetSavePoint()
+	ensure.Nil(t, txn.Put(givenKey2, givenVal))
+	ensure.Nil(t, txn.RollbackToSavePoint())
+	ensure.Nil(t, txn.Commit())
+
+	v1, err := db.Get(ro, givenKey1)
+	defer v1.Release()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v1.Data(), givenVal)
+	v2, err := db.Get(ro, givenKey2)
+	ensure.Nil(t, err)
+	ensure.True(t, v2.Data() == nil)
+}
+
+func TestTransactionSnapshot(t *testing.T) {
+	db := newTestTransactionDB(t, "TestTransactionSnapshot")
+	defer db.Release()
+
+	var (
+		givenKey = []byte("hello")
+		wo       = NewWriteOptions()
+		ro       = NewReadOptions()
+		to       = NewTransactionOptions()
+	)
+	defer wo.Release()
+	defer ro.Release()
+	defer to.Release()
+
+	// without SetSetSnapshot the transaction has no snapshot
+	txn := db.Begin(wo, to, nil)
+	ensure.True(t, txn.Snapshot() == nil)
+	txn.Release()
+
+	to.SetSetSnapshot(true)
+	txn = db.Begin(wo, to, nil)
+	defer txn.Release()
+	snap := txn.Snapshot()
+	ensure.NotNil(t, snap)
+	defer snap.Release()
+
+	// writes after the snapshot are not visible through it
+	ensure.Nil(t, db.Put(wo, givenKey, []byte("world")))
+	ro.SetSnapshot(snap)
+	v, err := db.Get(ro, givenKey)
+	ensure.Nil(t, err)
+	ensure.True(t, v.Data() == nil)
+}
+
+func TestTransactionLockConflict(t *testing.T) {
+	db := newTestTransactionDB(t, "TestTransactionLockConflict")
+	defer db.Release()
+
+	var (
+		givenKey = []byte("hello")
+		wo       = NewWriteOptions()
+		ro       = NewReadOptions()
+		to       = NewTransactionOptions()
+	)
+	defer wo.Release()
+	defer ro.Release()
+	defer to.Release()
+	to.SetLockTimeout(0)
+
+	txn1 := db.Begin(wo, to, nil)
+	defer txn1.Release()
+	v, err := txn1.GetForUpdate(ro, givenKey)
+	ensure.Nil(t, err)
+	v.Release()
+
+	// the key is locked by txn1
+	txn2 := db.Begin(wo, to, nil)
+	defer txn2.Release()
+	err = txn2.Put(givenKey, []byte("world"))
+	ensure.NotNil(t, err)
+	ensure.True(t, IsTransactionConflict(err))
+}
+
+func TestOptimisticTransactionConflict(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gorocksdb-TestOptimisticTransactionConflict")
+	ensure.Nil(t, err)
+
+	opts := NewOptions()
+	defer opts.Release()
+	opts.SetCreateIfMissing(true)
+	db, err := OpenOptimisticTransactionDB(opts, dir)
+	ensure.Nil(t, err)
+	defer db.Release()
+
+	var (
+		givenKey = []byte("hello")
+		wo       = NewWriteOptions()
+		ro       = NewReadOptions()
+		to       = NewOptimisticTransactionOptions()
+	)
+	defer wo.Release()
+	defer ro.Release()
+	defer to.Release()
+	txn := db.Begin(wo, to, nil)
+	defer txn.Release()
+	v, err := txn.GetForUpdate(ro, givenKey)
+	ensure.Nil(t, err)
+	v.Release()
+	ensure.Nil(t, txn.Put(givenKey, []byte("txn")))
+
+	// a write outside the transaction after the read causes a conflict
+	ensure.Nil(t, db.BaseDB().Put(wo, givenKey, []byte("outside")))
+	err = txn.Commit()
+	ensure.NotNil(t, err)
+	ensure.True(t, IsTransactionConflict(err))
+}
+
+func newTestTransactionDB(t *testing.T, name string) *TransactionDB {
+	dir, err := ioutil.TempDir("", "gorocksdb-"+name)
+	ensure.Nil(t, err)
+
+	opts := NewOptions()
+	t.Cleanup(opts.Release)
+	opts.SetCreateIfMissing(true)
+	txnDBOpts := NewTransactionDBOptions()
+	t.Cleanup(txnDBOpts.Release)
+	db, err := OpenTransactionDB(opts, txnDBOpts, dir)
+	ensure.Nil(t, err)
+
+	return db
+}
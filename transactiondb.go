@@ -0,0 +1,359 @@
+package gorocksdb
+
+// #include <stdlib.h>
+// #include "rocksdb/c.h"
+import "C"
+import (
+	"errors"
+	"unsafe"
+)
+
+// TransactionDB is a reusable handle to a RocksDB database on disk which
+// supports pessimistic transactions, created by OpenTransactionDB.
+type TransactionDB struct {
+	c *C.rocksdb_transactiondb_t
+}
+
+// newNativeTransactionDB creates a TransactionDB object.
+func newNativeTransactionDB(c *C.rocksdb_transactiondb_t) *TransactionDB {
+	db := &TransactionDB{c: c}
+	trackHandle(db)
+	return db
+}
+
+// OpenTransactionDB opens a database with the specified options which
+// supports pessimistic transactions.
+func OpenTransactionDB(opts *Options, txnDBOpts *TransactionDBOptions, name string) (*TransactionDB, error) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	var cErr *C.char
+	db := C.rocksdb_transactiondb_open(opts.c, txnDBOpts.c, cName, &cErr)
+	if cErr != nil {
+		return nil, convertErr(cErr)
+	}
+	return newNativeTransactionDB(db), nil
+}
+
+// OpenTransactionDBCFs opens a database with the specified column families
+// which supports pessimistic transactions.
+func OpenTransactionDBCFs(
+	opts *Options,
+	txnDBOpts *TransactionDBOptions,
+	name string,
+	cfNames []string,
+	cfOpts []*Options,
+) (*TransactionDB, []*CF, error) {
+	numCFs := len(cfNames)
+	if numCFs != len(cfOpts) {
+		return nil, nil, errors.New("must provide the same number of column family names and options")
+	}
+
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	cNames := make([]*C.char, numCFs)
+	for i, s := range cfNames {
+		cNames[i] = C.CString(s)
+	}
+	defer func() {
+		for _, s := range cNames {
+			C.free(unsafe.Pointer(s))
+		}
+	}()
+
+	cOpts := make([]*C.rocksdb_options_t, numCFs)
+	for i, o := range cfOpts {
+		cOpts[i] = o.c
+	}
+
+	cHandles := make([]*C.rocksdb_column_family_handle_t, numCFs)
+
+	var cErr *C.char
+	db := C.rocksdb_transactiondb_open_column_families(
+		opts.c,
+		txnDBOpts.c,
+		cName,
+		C.int(numCFs),
+		&cNames[0],
+		&cOpts[0],
+		&cHandles[0],
+		&cErr,
+	)
+	if cErr != nil {
+		return nil, nil, convertErr(cErr)
+	}
+
+	cfHandles := make([]*CF, numCFs)
+	for i, c := range cHandles {
+		cfHandles[i] = newNativeCF(c)
+	}
+
+	return newNativeTransactionDB(db), cfHandles, nil
+}
+
+// Begin begins a new transaction. If oldTxn is not nil it is reused for the
+// new transaction instead of allocating a new one, and is returned.
+func (db *TransactionDB) Begin(opts *WriteOptions, txnOpts *TransactionOptions, oldTxn *Transaction) *Transaction {
+	if oldTxn != nil {
+		oldTxn.c = C.rocksdb_transaction_begin(db.c, opts.c, txnOpts.c, oldTxn.c)
+		return oldTxn
+	}
+	return newNativeTransaction(C.rocksdb_transaction_begin(db.c, opts.c, txnOpts.c, nil))
+}
+
+// Get returns the data associated with the key from the database.
+func (db *TransactionDB) Get(opts *ReadOptions, key []byte) (*Slice, error) {
+	var cValLen C.size_t
+	var cErr *C.char
+	cValue := C.rocksdb_transactiondb_get(
+		db.c, opts.c, byteToChar(key), C.size_t(len(key)), &cValLen, &cErr)
+	if cErr != nil {
+		return nil, convertTxnErr(cErr)
+	}
+	return newSlice(cValue, cValLen), nil
+}
+
+// GetCF returns the data associated with the key from the database and column family.
+func (db *TransactionDB) GetCF(opts *ReadOptions, cf *CF, key []byte) (*Slice, error) {
+	var (
+		cErr    *C.char
+		cValLen C.size_t
+		cKey    = byteToChar(key)
+	)
+	cValue := C.rocksdb_transactiondb_get_cf(db.c, opts.c, cf.c, cKey, C.size_t(len(key)), &cValLen, &cErr)
+	if cErr != nil {
+		return nil, convertTxnErr(cErr)
+	}
+	return newSlice(cValue, cValLen), nil
+}
+
+// Put writes data associated with a key to the database. It waits for any
+// transaction holding a lock on the key as configured by
+// TransactionDBOptions.SetDefaultLockTimeout.
+func (db *TransactionDB) Put(opts *WriteOptions, key, value []byte) error {
+	var (
+		cErr   *C.char
+		cKey   = byteToChar(key)
+		cValue = byteToChar(value)
+	)
+	C.rocksdb_transactiondb_put(db.c, opts.c, cKey, C.size_t(len(key)), cValue, C.size_t(len(value)), &cErr)
+	return convertTxnErr(cErr)
+}
+
+// PutCF writes data associated with a key to the database and column family.
+func (db *TransactionDB) PutCF(opts *WriteOptions, cf *CF, key, value []byte) error {
+	var (
+		cErr   *C.char
+		cKey   = byteToChar(key)
+		cValue = byteToChar(value)
+	)
+	C.rocksdb_transactiondb_put_cf(db.c, opts.c, cf.c, cKey, C.size_t(len(key)), cValue, C.size_t(len(value)), &cErr)
+	return convertTxnErr(cErr)
+}
+
+// Delete removes the data associated with the key from the database.
+func (db *TransactionDB) Delete(opts *WriteOptions, key []byte) error {
+	var (
+		cErr *C.char
+		cKey = byteToChar(key)
+	)
+	C.rocksdb_transactiondb_delete(db.c, opts.c, cKey, C.size_t(len(key)), &cErr)
+	return convertTxnErr(cErr)
+}
+
+// DeleteCF removes the data associated with the key from the database and column family.
+func (db *TransactionDB) DeleteCF(opts *WriteOptions, cf *CF, key []byte) error {
+	var (
+		cErr *C.char
+		cKey = byteToChar(key)
+	)
+	C.rocksdb_transactiondb_delete_cf(db.c, opts.c, cf.c, cKey, C.size_t(len(key)), &cErr)
+	return convertTxnErr(cErr)
+}
+
+// Merge merges the data associated with the key with the actual data in the database.
+func (db *TransactionDB) Merge(opts *WriteOptions, key []byte, value []byte) error {
+	var (
+		cErr   *C.char
+		cKey   = byteToChar(key)
+		cValue = byteToChar(value)
+	)
+	C.rocksdb_transactiondb_merge(db.c, opts.c, cKey, C.size_t(len(key)), cValue, C.size_t(len(value)), &cErr)
+	return convertTxnErr(cErr)
+}
+
+// MergeCF merges the data associated with the key with the actual data in the
+// database and column family.
+func (db *TransactionDB) MergeCF(opts *WriteOptions, cf *CF, key []byte, value []byte) error {
+	var (
+		cErr   *C.char
+		cKey   = byteToChar(key)
+		cValue = byteToChar(value)
+	)
+	C.rocksdb_transactiondb_merge_cf(db.c, opts.c, cf.c, cKey, C.size_t(len(key)), cValue, C.size_t(len(value)), &cErr)
+	return convertTxnErr(cErr)
+}
+
+// Write writes a WriteBatch to the database.
+func (db *TransactionDB) Write(opts *WriteOptions, batch *WriteBatch) error {
+	var cErr *C.char
+	C.rocksdb_transactiondb_write(db.c, opts.c, batch.c, &cErr)
+	return convertTxnErr(cErr)
+}
+
+// NewIterator returns an Iterator over the the database that uses the
+// ReadOptions given.
+func (db *TransactionDB) NewIterator(opts *ReadOptions) *Iterator {
+	cIter := C.rocksdb_transactiondb_create_iterator(db.c, opts.c)
+	return newNativeIterator(cIter)
+}
+
+// NewIteratorCF returns an Iterator over the the database and column family
+// that uses the ReadOptions given.
+func (db *TransactionDB) NewIteratorCF(opts *ReadOptions, cf *CF) *Iterator {
+	cIter := C.rocksdb_transactiondb_create_iterator_cf(db.c, opts.c, cf.c)
+	return newNativeIterator(cIter)
+}
+
+// NewSnapshot creates a new snapshot of the database.
+func (db *TransactionDB) NewSnapshot() *Snapshot {
+	cSnap := C.rocksdb_transactiondb_create_snapshot(db.c)
+	return newNativeTransactionDBSnapshot(cSnap, db.c)
+}
+
+// CreateCF create a new column family.
+func (db *TransactionDB) CreateCF(opts *Options, name string) (*CF, error) {
+	var (
+		cErr  *C.char
+		cName = C.CString(name)
+	)
+	defer C.free(unsafe.Pointer(cName))
+	cHandle := C.rocksdb_transactiondb_create_column_family(db.c, opts.c, cName, &cErr)
+	if cErr != nil {
+		return nil, convertErr(cErr)
+	}
+	return newNativeCF(cHandle), nil
+}
+
+// Release closes the database.
+func (db *TransactionDB) Release() {
+	C.rocksdb_transactiondb_close(db.c)
+	db.c = nil
+	untrackHandle(db)
+}
+
+// OptimisticTransactionDB is a reusable handle to a RocksDB database on disk
+// which supports optimistic transactions, created by
+// OpenOptimisticTransactionDB. Conflicts are only checked when a transaction
+// is committed, so no locks are held while it runs.
+type OptimisticTransactionDB struct {
+	c    *C.rocksdb_optimistictransactiondb_t
+	base *DB
+}
+
+// newNativeOptimisticTransactionDB creates an OptimisticTransactionDB object.
+func newNativeOptimisticTransactionDB(c *C.rocksdb_optimistictransactiondb_t) *OptimisticTransactionDB {
+	db := &OptimisticTransactionDB{c: c}
+	trackHandle(db)
+	return db
+}
+
+// OpenOptimisticTransactionDB opens a database with the specified options
+// which supports optimistic transactions.
+func OpenOptimisticTransactionDB(opts *Options, name string) (*OptimisticTransactionDB, error) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	var cErr *C.char
+	db := C.rocksdb_optimistictransactiondb_open(opts.c, cName, &cErr)
+	if cErr != nil {
+		return nil, convertErr(cErr)
+	}
+	return newNativeOptimisticTransactionDB(db), nil
+}
+
+// OpenOptimisticTransactionDBCFs opens a database with the specified column
+// families which supports optimistic transactions.
+func OpenOptimisticTransactionDBCFs(
+	opts *Options,
+	name string,
+	cfNames []string,
+	cfOpts []*Options,
+) (*OptimisticTransactionDB, []*CF, error) {
+	numCFs := len(cfNames)
+	if numCFs != len(cfOpts) {
+		return nil, nil, errors.New("must provide the same number of column family names and options")
+	}
+
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	cNames := make([]*C.char, numCFs)
+	for i, s := range cfNames {
+		cNames[i] = C.CString(s)
+	}
+	defer func() {
+		for _, s := range cNames {
+			C.free(unsafe.Pointer(s))
+		}
+	}()
+
+	cOpts := make([]*C.rocksdb_options_t, numCFs)
+	for i, o := range cfOpts {
+		cOpts[i] = o.c
+	}
+
+	cHandles := make([]*C.rocksdb_column_family_handle_t, numCFs)
+
+	var cErr *C.char
+	db := C.rocksdb_optimistictransactiondb_open_column_families(
+		opts.c,
+		cName,
+		C.int(numCFs),
+		&cNames[0],
+		&cOpts[0],
+		&cHandles[0],
+		&cErr,
+	)
+	if cErr != nil {
+		return nil, nil, convertErr(cErr)
+	}
+
+	cfHandles := make([]*CF, numCFs)
+	for i, c := range cHandles {
+		cfHandles[i] = newNativeCF(c)
+	}
+
+	return newNativeOptimisticTransactionDB(db), cfHandles, nil
+}
+
+// Begin begins a new transaction. If oldTxn is not nil it is reused for the
+// new transaction instead of allocating a new one, and is returned.
+func (db *OptimisticTransactionDB) Begin(opts *WriteOptions, txnOpts *OptimisticTransactionOptions, oldTxn *Transaction) *Transaction {
+	if oldTxn != nil {
+		oldTxn.c = C.rocksdb_optimistictransaction_begin(db.c, opts.c, txnOpts.c, oldTxn.c)
+		return oldTxn
+	}
+	return newNativeTransaction(C.rocksdb_optimistictransaction_begin(db.c, opts.c, txnOpts.c, nil))
+}
+
+// BaseDB returns a DB handle to the underlying database which can be used
+// for reads and non-transactional writes. The returned DB is owned by the
+// OptimisticTransactionDB and must not be released by the caller.
+func (db *OptimisticTransactionDB) BaseDB() *DB {
+	if db.base == nil {
+		db.base = &DB{c: C.rocksdb_optimistictransactiondb_get_base_db(db.c)}
+	}
+	return db.base
+}
+
+// Release closes the database.
+func (db *OptimisticTransactionDB) Release() {
+	if db.base != nil {
+		C.rocksdb_optimistictransactiondb_close_base_db(db.base.c)
+		db.base.c = nil
+		db.base = nil
+	}
+	C.rocksdb_optimistictransactiondb_close(db.c)
+	db.c = nil
+	untrackHandle(db)
+}
@@ -0,0 +1,15 @@
+#include "rocksdb/utilities/stackable_db.h"
+#include "gorocksdb_internal.h"
+
+using rocksdb::StackableDB;
+
+rocksdb_t* gorocksdb_ttl_base_db(rocksdb_t* db) {
+    rocksdb_t* base = new rocksdb_t;
+    base->rep = static_cast<StackableDB*>(db->rep)->GetBaseDB();
+    return base;
+}
+
+void gorocksdb_ttl_base_db_destroy(rocksdb_t* base) {
+    // the database itself is owned by the TTL database
+    delete base;
+}
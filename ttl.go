@@ -0,0 +1,260 @@
+package gorocksdb
+
+// #include <stdlib.h>
+// #include "rocksdb/c.h"
+// #include "gorocksdb.h"
+import "C"
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+	"unsafe"
+)
+
+// ttlTimestampSize is the size of the write timestamp RocksDB appends to
+// every value stored in a database opened with a TTL.
+const ttlTimestampSize = 4
+
+// OpenDBWithTTL opens a database in which entries expire ttl after they
+// were written. Expired entries are dropped by compaction, so until then
+// reads may still return them unless ReadOptions.SetHideExpired is used.
+// The TTL is rounded to whole seconds; a ttl <= 0 disables expiration.
+//
+// Values are read and written as usual: RocksDB adds the write timestamp
+// on Put, Merge and Write and strips it on Get and in iterators.
+func OpenDBWithTTL(opts *Options, name string, ttl time.Duration) (*DB, error) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	var cErr *C.char
+	db := C.rocksdb_open_with_ttl(opts.c, cName, ttlSeconds(ttl), &cErr)
+	if cErr != nil {
+		return nil, convertErr(cErr)
+	}
+	return newTTLDB(db, int32(ttlSeconds(ttl))), nil
+}
+
+// OpenDBWithTTLCFs opens a database with the specified column families in
+// which the entries of each column family expire after the TTL at the same
+// index in ttls. See OpenDBWithTTL.
+func OpenDBWithTTLCFs(
+	opts *Options,
+	name string,
+	cfNames []string,
+	cfOpts []*Options,
+	ttls []time.Duration,
+) (*DB, []*CF, error) {
+	numCFs := len(cfNames)
+	if numCFs != len(cfOpts) || numCFs != len(ttls) {
+		return nil, nil, errors.New("must provide the same number of column family names, options and ttls")
+	}
+
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	cNames := make([]*C.char, numCFs)
+	for i, s := range cfNames {
+		cNames[i] = C.CString(s)
+	}
+	defer func() {
+		for _, s := range cNames {
+			C.free(unsafe.Pointer(s))
+		}
+	}()
+
+	cOpts := make([]*C.rocksdb_options_t, numCFs)
+	for i, o := range cfOpts {
+		cOpts[i] = o.c
+	}
+
+	cTTLs := make([]C.int, numCFs)
+	for i, ttl := range ttls {
+		cTTLs[i] = ttlSeconds(ttl)
+	}
+
+	cHandles := make([]*C.rocksdb_column_family_handle_t, numCFs)
+
+	var cErr *C.char
+	db := C.rocksdb_open_column_families_with_ttl(
+		opts.c,
+		cName,
+		C.int(numCFs),
+		&cNames[0],
+		&cOpts[0],
+		&cHandles[0],
+		&cTTLs[0],
+		&cErr,
+	)
+	if cErr != nil {
+		return nil, nil, convertErr(cErr)
+	}
+
+	ttlDB := newTTLDB(db, 0)
+	cfHandles := make([]*CF, numCFs)
+	for i, c := range cHandles {
+		cfHandles[i] = newNativeCF(c)
+		cfHandles[i].ttl = int32(cTTLs[i])
+		if cfNames[i] == "default" {
+			ttlDB.ttl = int32(cTTLs[i])
+		}
+	}
+
+	return ttlDB, cfHandles, nil
+}
+
+// CreateCFWithTTL creates a new column family in a database opened with
+// OpenDBWithTTL or OpenDBWithTTLCFs, in which entries expire after ttl.
+func (db *DB) CreateCFWithTTL(opts *Options, name string, ttl time.Duration) (*CF, error) {
+	if db.ttlBase == nil {
+		return nil, errors.New("the database was not opened with a ttl")
+	}
+	var (
+		cErr  *C.char
+		cName = C.CString(name)
+	)
+	defer C.free(unsafe.Pointer(cName))
+	cHandle := C.rocksdb_create_column_family_with_ttl(db.c, opts.c, cName, ttlSeconds(ttl), &cErr)
+	if cErr != nil {
+		return nil, convertErr(cErr)
+	}
+	cf := newNativeCF(cHandle)
+	cf.ttl = int32(ttlSeconds(ttl))
+	return cf, nil
+}
+
+// newTTLDB creates a DB object for a database opened with a TTL.
+func newTTLDB(c *C.rocksdb_t, ttl int32) *DB {
+	db := newNativeDB(c)
+	db.ttlBase = C.gorocksdb_ttl_base_db(c)
+	db.ttl = ttl
+	return db
+}
+
+// ttlSeconds converts a TTL to the seconds used by RocksDB, rounding up so
+// that short positive TTLs do not disable expiration.
+func ttlSeconds(ttl time.Duration) C.int {
+	if ttl <= 0 {
+		return 0
+	}
+	return C.int((ttl + time.Second - 1) / time.Second)
+}
+
+// ttlExpiry decides whether raw values read from the base database of a TTL
+// database have expired.
+type ttlExpiry struct {
+	ttl int32
+	now int64
+}
+
+// newTTLExpiry returns the expiry of values of a column family with the
+// given TTL at the current time.
+func newTTLExpiry(ttl int32) *ttlExpiry {
+	return &ttlExpiry{ttl: ttl, now: time.Now().Unix()}
+}
+
+// expired reports whether a value including its timestamp has expired,
+// using the same rule as RocksDB's compaction filter.
+func (e *ttlExpiry) expired(value []byte) bool {
+	if e.ttl <= 0 || len(value) < ttlTimestampSize {
+		return false
+	}
+	ts := int32(binary.LittleEndian.Uint32(value[len(value)-ttlTimestampSize:]))
+	return int64(ts)+int64(e.ttl) < e.now
+}
+
+// getUnexpired reads a key from the base database of a TTL database,
+// returning an empty Slice if it has expired. cf may be nil for the default
+// column family.
+func (db *DB) getUnexpired(opts *ReadOptions, cf *CF, key []byte) (*Slice, error) {
+	var (
+		cErr    *C.char
+		cValLen C.size_t
+		cValue  *C.char
+		cKey    = byteToChar(key)
+		ttl     = db.ttl
+	)
+	if cf == nil {
+		cValue = C.rocksdb_get(db.ttlBase, opts.c, cKey, C.size_t(len(key)), &cValLen, &cErr)
+	} else {
+		cValue = C.rocksdb_get_cf(db.ttlBase, opts.c, cf.c, cKey, C.size_t(len(key)), &cValLen, &cErr)
+		ttl = cf.ttl
+	}
+	if cErr != nil {
+		return nil, convertErr(cErr)
+	}
+	if cValue == nil {
+		return newSlice(nil, 0), nil
+	}
+	if newTTLExpiry(ttl).expired(charToByte(cValue, cValLen)) {
+		C.free(unsafe.Pointer(cValue))
+		return newSlice(nil, 0), nil
+	}
+	if cValLen >= ttlTimestampSize {
+		cValLen -= ttlTimestampSize
+	}
+	return newSlice(cValue, cValLen), nil
+}
+
+// getPinnedUnexpired reads a key like getUnexpired without copying it.
+func (db *DB) getPinnedUnexpired(opts *ReadOptions, cf *CF, key []byte) (*PinnableSliceHandle, error) {
+	var (
+		cErr    *C.char
+		cHandle *C.rocksdb_pinnableslice_t
+		cKey    = byteToChar(key)
+		ttl     = db.ttl
+	)
+	if cf == nil {
+		cHandle = C.rocksdb_get_pinned(db.ttlBase, opts.c, cKey, C.size_t(len(key)), &cErr)
+	} else {
+		cHandle = C.rocksdb_get_pinned_cf(db.ttlBase, opts.c, cf.c, cKey, C.size_t(len(key)), &cErr)
+		ttl = cf.ttl
+	}
+	if cErr != nil {
+		return nil, convertErr(cErr)
+	}
+	h := newNativePinnableSliceHandle(cHandle)
+	if newTTLExpiry(ttl).expired(h.Data()) {
+		h.Release()
+		return h, nil
+	}
+	h.suffix = ttlTimestampSize
+	return h, nil
+}
+
+// hideExpiredMultiGetValue drops a value read by a multi get from the base
+// database of a TTL database if it has expired, and strips its timestamp
+// otherwise.
+func hideExpiredMultiGetValue(expiry *ttlExpiry, cValue **C.char, cValueSize *C.size_t, cErr *C.char) {
+	if cErr != nil || *cValue == nil {
+		return
+	}
+	if expiry.expired(charToByte(*cValue, *cValueSize)) {
+		C.free(unsafe.Pointer(*cValue))
+		*cValue, *cValueSize = nil, 0
+		return
+	}
+	if *cValueSize >= ttlTimestampSize {
+		*cValueSize -= ttlTimestampSize
+	}
+}
+
+// hideExpired reports whether reads with opts must skip expired entries.
+func (db *DB) hideExpired(opts *ReadOptions) bool {
+	return opts.hideExpired && db.ttlBase != nil
+}
+
+// skipExpired moves the iterator forward or backward until it is positioned
+// on an entry which has not expired.
+func (i *Iterator) skipExpired(forward bool) {
+	for C.rocksdb_iter_valid(i.c) != 0 {
+		var cLen C.size_t
+		cVal := C.rocksdb_iter_value(i.c, &cLen)
+		if !i.expiry.expired(charToByte(cVal, cLen)) {
+			return
+		}
+		if forward {
+			C.rocksdb_iter_next(i.c)
+		} else {
+			C.rocksdb_iter_prev(i.c)
+		}
+	}
+}
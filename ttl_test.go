@@ -0,0 +1,167 @@
+package gorocksdb
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestOpenDBWithTTL(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gorocksdb-TestOpenDBWithTTL")
+	ensure.Nil(t, err)
+
+	opts := NewOptions()
+	defer opts.Release()
+	opts.SetCreateIfMissing(true)
+	db, err := OpenDBWithTTL(opts, dir, time.Second)
+	ensure.Nil(t, err)
+	defer db.Release()
+
+	var (
+		givenKey = []byte("hello")
+		givenVal = []byte("world")
+		wo       = NewWriteOptions()
+		ro       = NewReadOptions()
+		hideRO   = NewReadOptions()
+	)
+	defer wo.Release()
+	defer ro.Release()
+	defer hideRO.Release()
+	hideRO.SetHideExpired(true)
+
+	batch := NewWriteBatch()
+	defer batch.Release()
+	batch.Put(givenKey, givenVal)
+	ensure.Nil(t, db.Write(wo, batch))
+
+	// the timestamp is not visible to callers
+	v1, err := db.Get(ro, givenKey)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v1.Data(), givenVal)
+	v1.Release()
+	v2, err := db.Get(hideRO, givenKey)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v2.Data(), givenVal)
+	v2.Release()
+
+	time.Sleep(2100 * time.Millisecond)
+
+	// expired but not compacted yet
+	v3, err := db.Get(ro, givenKey)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v3.Data(), givenVal)
+	v3.Release()
+	v4, err := db.Get(hideRO, givenKey)
+	ensure.Nil(t, err)
+	ensure.True(t, v4.Data() == nil)
+
+	h, err := db.GetPinned(hideRO, givenKey)
+	ensure.Nil(t, err)
+	ensure.False(t, h.Exists())
+
+	iter := db.NewIterator(hideRO)
+	iter.SeekToFirst()
+	ensure.False(t, iter.Valid())
+	ensure.Nil(t, iter.Err())
+	iter.Release()
+
+	ensure.Nil(t, db.CompactRange(Range{nil, nil}))
+	v5, err := db.Get(ro, givenKey)
+	ensure.Nil(t, err)
+	ensure.True(t, v5.Data() == nil)
+}
+
+func TestOpenDBWithTTLCFs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gorocksdb-TestOpenDBWithTTLCFs")
+	ensure.Nil(t, err)
+
+	opts := NewOptions()
+	defer opts.Release()
+	opts.SetCreateIfMissing(true)
+	opts.SetCreateIfMissingColumnFamilies(true)
+	db, cfs, err := OpenDBWithTTLCFs(opts, dir, []string{"default", "short"}, []*Options{opts, opts}, []time.Duration{0, time.Second})
+	ensure.Nil(t, err)
+	defer db.Release()
+	defer cfs[0].Release()
+	defer cfs[1].Release()
+
+	var (
+		wo = NewWriteOptions()
+		ro = NewReadOptions()
+	)
+	defer wo.Release()
+	defer ro.Release()
+	ro.SetHideExpired(true)
+
+	ensure.Nil(t, db.Put(wo, []byte("key1"), []byte("forever")))
+	ensure.Nil(t, db.PutCF(wo, cfs[1], []byte("key2"), []byte("short")))
+	ensure.Nil(t, db.PutCF(wo, cfs[1], []byte("key3"), []byte("short")))
+
+	time.Sleep(2100 * time.Millisecond)
+
+	long, err := db.CreateCFWithTTL(opts, "long", time.Hour)
+	ensure.Nil(t, err)
+	defer long.Release()
+	ensure.Nil(t, db.PutCF(wo, long, []byte("key4"), []byte("long")))
+
+	v1, err := db.Get(ro, []byte("key1"))
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v1.Data(), []byte("forever"))
+	v1.Release()
+
+	h, err := db.GetPinned(ro, []byte("key1"))
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, h.Data(), []byte("forever"))
+	h.Release()
+
+	v2, err := db.GetCF(ro, cfs[1], []byte("key2"))
+	ensure.Nil(t, err)
+	ensure.True(t, v2.Data() == nil)
+
+	v3, err := db.GetCF(ro, long, []byte("key4"))
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v3.Data(), []byte("long"))
+	v3.Release()
+
+	values, errs := db.MultiGet(ro, [][]byte{[]byte("key1"), []byte("key2")})
+	ensure.DeepEqual(t, errs, []error{nil, nil})
+	ensure.DeepEqual(t, values[0].Data(), []byte("forever"))
+	ensure.True(t, values[1] == nil)
+	values[0].Release()
+
+	values, errs = db.MultiGetCF(ro, []*CF{cfs[0], cfs[1], long}, [][]byte{[]byte("key1"), []byte("key2"), []byte("key4")})
+	ensure.DeepEqual(t, errs, []error{nil, nil, nil})
+	ensure.DeepEqual(t, values[0].Data(), []byte("forever"))
+	ensure.True(t, values[1] == nil)
+	ensure.DeepEqual(t, values[2].Data(), []byte("long"))
+	values[0].Release()
+	values[2].Release()
+
+	iter := db.NewIteratorCF(ro, cfs[1])
+	defer iter.Release()
+	iter.SeekToLast()
+	ensure.False(t, iter.Valid())
+
+	longIter := db.NewIteratorCF(ro, long)
+	defer longIter.Release()
+	longIter.SeekToFirst()
+	ensure.True(t, longIter.Valid())
+	ensure.DeepEqual(t, longIter.Value().Data(), []byte("long"))
+}
+
+func TestTTLExpiry(t *testing.T) {
+	value := func(ts int32) []byte {
+		v := []byte("value0000")
+		binary.LittleEndian.PutUint32(v[len(v)-4:], uint32(ts))
+		return v
+	}
+	e := &ttlExpiry{ttl: 10, now: 100}
+	ensure.False(t, e.expired(value(95)))
+	ensure.False(t, e.expired(value(90)))
+	ensure.True(t, e.expired(value(89)))
+	ensure.False(t, e.expired([]byte("v")))
+	ensure.False(t, (&ttlExpiry{now: 100}).expired(value(0)))
+}
@@ -2,7 +2,11 @@ package gorocksdb
 
 // #include "rocksdb/c.h"
 import "C"
-import "io"
+import (
+	"fmt"
+	"io"
+	"math"
+)
 
 // WriteBatch is a batching of Puts, Merges and Deletes.
 type WriteBatch struct {
@@ -16,7 +20,9 @@ func NewWriteBatch() *WriteBatch {
 
 // newNativeWriteBatch create a WriteBatch object.
 func newNativeWriteBatch(c *C.rocksdb_writebatch_t) *WriteBatch {
-	return &WriteBatch{c}
+	w := &WriteBatch{c}
+	trackHandle(w)
+	return w
 }
 
 // WriteBatchFrom creates a write batch from a serialized WriteBatch.
@@ -65,6 +71,44 @@ func (w *WriteBatch) DeleteCF(cf *CF, key []byte) {
 	C.rocksdb_writebatch_delete_cf(w.c, cf.c, cKey, C.size_t(len(key)))
 }
 
+// SingleDelete queues a single deletion of the data at key. See
+// DB.SingleDelete for the restrictions.
+func (w *WriteBatch) SingleDelete(key []byte) {
+	cKey := byteToChar(key)
+	C.rocksdb_writebatch_singledelete(w.c, cKey, C.size_t(len(key)))
+}
+
+// SingleDeleteCF queues a single deletion of the data at key in a column
+// family. See DB.SingleDelete for the restrictions.
+func (w *WriteBatch) SingleDeleteCF(cf *CF, key []byte) {
+	cKey := byteToChar(key)
+	C.rocksdb_writebatch_singledelete_cf(w.c, cf.c, cKey, C.size_t(len(key)))
+}
+
+// DeleteRange queues a deletion of the data at all keys in the range
+// [startKey, endKey).
+func (w *WriteBatch) DeleteRange(startKey, endKey []byte) {
+	cStartKey := byteToChar(startKey)
+	cEndKey := byteToChar(endKey)
+	C.rocksdb_writebatch_delete_range(w.c, cStartKey, C.size_t(len(startKey)), cEndKey, C.size_t(len(endKey)))
+}
+
+// DeleteRangeCF queues a deletion of the data at all keys in the range
+// [startKey, endKey) in a column family.
+func (w *WriteBatch) DeleteRangeCF(cf *CF, startKey, endKey []byte) {
+	cStartKey := byteToChar(startKey)
+	cEndKey := byteToChar(endKey)
+	C.rocksdb_writebatch_delete_range_cf(w.c, cf.c, cStartKey, C.size_t(len(startKey)), cEndKey, C.size_t(len(endKey)))
+}
+
+// PutLogData queues a blob of arbitrary data which is stored in the write
+// ahead log along with the batch, but not applied to the database. It is
+// visible to consumers of the log and to WriteBatchIterator.
+func (w *WriteBatch) PutLogData(blob []byte) {
+	cBlob := byteToChar(blob)
+	C.rocksdb_writebatch_put_log_data(w.c, cBlob, C.size_t(len(blob)))
+}
+
 // Data returns the serialized version of this batch.
 func (w *WriteBatch) Data() []byte {
 	var cSize C.size_t
@@ -95,6 +139,7 @@ func (w *WriteBatch) Clear() {
 func (w *WriteBatch) Release() {
 	C.rocksdb_writebatch_destroy(w.c)
 	w.c = nil
+	untrackHandle(w)
 }
 
 // WriteBatchRecordType describes the type of a batch record.
@@ -102,14 +147,54 @@ type WriteBatchRecordType byte
 
 // Types of batch records.
 const (
-	WriteBatchRecordTypeDeletion WriteBatchRecordType = 0x0
-	WriteBatchRecordTypeValue    WriteBatchRecordType = 0x1
-	WriteBatchRecordTypeMerge    WriteBatchRecordType = 0x2
-	WriteBatchRecordTypeLogData  WriteBatchRecordType = 0x3
+	WriteBatchRecordTypeDeletion       WriteBatchRecordType = 0x0
+	WriteBatchRecordTypeValue          WriteBatchRecordType = 0x1
+	WriteBatchRecordTypeMerge          WriteBatchRecordType = 0x2
+	WriteBatchRecordTypeLogData        WriteBatchRecordType = 0x3
+	WriteBatchRecordTypeSingleDeletion WriteBatchRecordType = 0x7
+	WriteBatchRecordTypeRangeDeletion  WriteBatchRecordType = 0xF
 )
 
+// Types of the markers written by two phase commit transactions and of
+// padding records.
+const (
+	WriteBatchRecordTypeBeginPrepareXID WriteBatchRecordType = 0x9
+	WriteBatchRecordTypeEndPrepareXID   WriteBatchRecordType = 0xA
+	WriteBatchRecordTypeCommitXID       WriteBatchRecordType = 0xB
+	WriteBatchRecordTypeRollbackXID     WriteBatchRecordType = 0xC
+	WriteBatchRecordTypeNoop            WriteBatchRecordType = 0xD
+)
+
+// Tags of batch records which are prefixed with a column family id. They
+// are reported as the corresponding type above with WriteBatchRecord.CF set.
+const (
+	writeBatchRecordTypeCFDeletion       WriteBatchRecordType = 0x4
+	writeBatchRecordTypeCFValue          WriteBatchRecordType = 0x5
+	writeBatchRecordTypeCFMerge          WriteBatchRecordType = 0x6
+	writeBatchRecordTypeCFSingleDeletion WriteBatchRecordType = 0x8
+	writeBatchRecordTypeCFRangeDeletion  WriteBatchRecordType = 0xE
+)
+
+// UnknownRecordTypeError is returned by WriteBatchIterator.Error if the
+// batch contains a record of a type the iterator cannot decode.
+type UnknownRecordTypeError struct {
+	Type WriteBatchRecordType
+}
+
+func (e *UnknownRecordTypeError) Error() string {
+	return fmt.Sprintf("gorocksdb: unknown write batch record type %#x", byte(e.Type))
+}
+
 // WriteBatchRecord represents a record inside a WriteBatch.
+//
+// CF is the id of the column family the record applies to, 0 being the
+// default column family. Range deletion records carry the start key of the
+// deleted range as Key and the exclusive end key as Value. LogData records
+// carry their blob as Value and have no Key. End prepare, commit and
+// rollback markers carry the transaction id as Value and have no Key, begin
+// prepare markers and noop records carry neither.
 type WriteBatchRecord struct {
+	CF    uint32
 	Key   []byte
 	Value []byte
 	Type  WriteBatchRecordType
@@ -129,34 +214,75 @@ func (i *WriteBatchIterator) Next() bool {
 		return false
 	}
 	// reset the current record
+	i.record.CF = 0
 	i.record.Key = nil
 	i.record.Value = nil
 
 	// parse the record type
 	recordType := WriteBatchRecordType(i.data[0])
+	hasCF := false
+	switch recordType {
+	case WriteBatchRecordTypeDeletion, WriteBatchRecordTypeValue,
+		WriteBatchRecordTypeMerge, WriteBatchRecordTypeLogData,
+		WriteBatchRecordTypeSingleDeletion, WriteBatchRecordTypeRangeDeletion,
+		WriteBatchRecordTypeBeginPrepareXID, WriteBatchRecordTypeEndPrepareXID,
+		WriteBatchRecordTypeCommitXID, WriteBatchRecordTypeRollbackXID,
+		WriteBatchRecordTypeNoop:
+	case writeBatchRecordTypeCFDeletion:
+		recordType, hasCF = WriteBatchRecordTypeDeletion, true
+	case writeBatchRecordTypeCFValue:
+		recordType, hasCF = WriteBatchRecordTypeValue, true
+	case writeBatchRecordTypeCFMerge:
+		recordType, hasCF = WriteBatchRecordTypeMerge, true
+	case writeBatchRecordTypeCFSingleDeletion:
+		recordType, hasCF = WriteBatchRecordTypeSingleDeletion, true
+	case writeBatchRecordTypeCFRangeDeletion:
+		recordType, hasCF = WriteBatchRecordTypeRangeDeletion, true
+	default:
+		i.err = &UnknownRecordTypeError{Type: recordType}
+		return false
+	}
 	i.record.Type = recordType
 	i.data = i.data[1:]
 
+	// parse the column family id
+	if hasCF {
+		x, n := i.decodeVarint(i.data)
+		if n == 0 {
+			i.err = io.ErrShortBuffer
+			return false
+		}
+		if x > math.MaxUint32 {
+			i.err = fmt.Errorf("gorocksdb: write batch column family id %d overflows uint32", x)
+			return false
+		}
+		i.record.CF = uint32(x)
+		i.data = i.data[n:]
+	}
+
+	switch recordType {
+	case WriteBatchRecordTypeBeginPrepareXID, WriteBatchRecordTypeNoop:
+		return true
+	case WriteBatchRecordTypeLogData, WriteBatchRecordTypeEndPrepareXID,
+		WriteBatchRecordTypeCommitXID, WriteBatchRecordTypeRollbackXID:
+		// parse the log data blob or the transaction id
+		i.record.Value = i.decodeSlice()
+		return i.err == nil
+	}
+
 	// parse the key
-	x, n := i.decodeVarint(i.data)
-	if n == 0 {
-		i.err = io.ErrShortBuffer
+	i.record.Key = i.decodeSlice()
+	if i.err != nil {
 		return false
 	}
-	k := n + int(x)
-	i.record.Key = i.data[n:k]
-	i.data = i.data[k:]
 
 	// parse the data
-	if recordType == WriteBatchRecordTypeValue || recordType == WriteBatchRecordTypeMerge {
-		x, n := i.decodeVarint(i.data)
-		if n == 0 {
-			i.err = io.ErrShortBuffer
+	if recordType == WriteBatchRecordTypeValue || recordType == WriteBatchRecordTypeMerge ||
+		recordType == WriteBatchRecordTypeRangeDeletion {
+		i.record.Value = i.decodeSlice()
+		if i.err != nil {
 			return false
 		}
-		k := n + int(x)
-		i.record.Value = i.data[n:k]
-		i.data = i.data[k:]
 	}
 	return true
 }
@@ -171,6 +297,20 @@ func (i *WriteBatchIterator) Error() error {
 	return i.err
 }
 
+// decodeSlice decodes a length prefixed slice from the front of the
+// remaining data.
+func (i *WriteBatchIterator) decodeSlice() []byte {
+	x, n := i.decodeVarint(i.data)
+	if n == 0 || x > uint64(len(i.data)-n) {
+		i.err = io.ErrShortBuffer
+		return nil
+	}
+	k := n + int(x)
+	slice := i.data[n:k]
+	i.data = i.data[k:]
+	return slice
+}
+
 func (i *WriteBatchIterator) decodeVarint(buf []byte) (x uint64, n int) {
 	// x, n already 0
 	for shift := uint(0); shift < 64; shift += 7 {
@@ -1,6 +1,8 @@
 package gorocksdb
 
 import (
+	"io"
+	"io/ioutil"
 	"testing"
 
 	"github.com/facebookgo/ensure"
@@ -73,3 +75,150 @@ func TestWriteBatchIterator(t *testing.T) {
 	// there shouldn't be any left
 	ensure.False(t, iter.Next())
 }
+
+func TestWriteBatchIteratorDeleteRange(t *testing.T) {
+	var (
+		givenStartKey = []byte("key1")
+		givenEndKey   = []byte("key9")
+	)
+	wb := NewWriteBatch()
+	defer wb.Release()
+	wb.DeleteRange(givenStartKey, givenEndKey)
+	ensure.DeepEqual(t, wb.Count(), 1)
+
+	iter := wb.NewIterator()
+	ensure.True(t, iter.Next())
+	record := iter.Record()
+	ensure.DeepEqual(t, record.Type, WriteBatchRecordTypeRangeDeletion)
+	ensure.DeepEqual(t, record.Key, givenStartKey)
+	ensure.DeepEqual(t, record.Value, givenEndKey)
+
+	ensure.False(t, iter.Next())
+	ensure.Nil(t, iter.Error())
+}
+
+func TestWriteBatchIteratorSingleDelete(t *testing.T) {
+	var (
+		givenKey1 = []byte("key1")
+		givenKey2 = []byte("key2")
+	)
+	wb := NewWriteBatch()
+	defer wb.Release()
+	wb.SingleDelete(givenKey1)
+	wb.Delete(givenKey2)
+
+	iter := wb.NewIterator()
+	ensure.True(t, iter.Next())
+	record := iter.Record()
+	ensure.DeepEqual(t, record.Type, WriteBatchRecordTypeSingleDeletion)
+	ensure.DeepEqual(t, record.Key, givenKey1)
+	ensure.True(t, record.Value == nil)
+
+	ensure.True(t, iter.Next())
+	record = iter.Record()
+	ensure.DeepEqual(t, record.Type, WriteBatchRecordTypeDeletion)
+	ensure.DeepEqual(t, record.Key, givenKey2)
+
+	ensure.False(t, iter.Next())
+	ensure.Nil(t, iter.Error())
+}
+
+func TestWriteBatchIteratorCF(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gorocksdb-TestWriteBatchIteratorCF")
+	ensure.Nil(t, err)
+
+	opts := NewOptions()
+	defer opts.Release()
+	opts.SetCreateIfMissingColumnFamilies(true)
+	opts.SetCreateIfMissing(true)
+	db, cfh, err := OpenDBCFs(opts, dir, []string{"default", "guide"}, []*Options{opts, opts})
+	ensure.Nil(t, err)
+	defer db.Release()
+	defer cfh[0].Release()
+	defer cfh[1].Release()
+
+	var (
+		givenKey1  = []byte("key1")
+		givenVal1  = []byte("val1")
+		givenKey2  = []byte("key2")
+		givenBlob  = []byte("blob")
+		givenStart = []byte("a")
+		givenEnd   = []byte("z")
+	)
+	wb := NewWriteBatch()
+	defer wb.Release()
+	wb.PutCF(cfh[1], givenKey1, givenVal1)
+	wb.PutLogData(givenBlob)
+	wb.MergeCF(cfh[1], givenKey1, givenVal1)
+	wb.DeleteCF(cfh[1], givenKey2)
+	wb.SingleDeleteCF(cfh[1], givenKey2)
+	wb.DeleteRangeCF(cfh[1], givenStart, givenEnd)
+	wb.PutCF(cfh[0], givenKey2, givenVal1)
+
+	expected := []WriteBatchRecord{
+		{CF: 1, Key: givenKey1, Value: givenVal1, Type: WriteBatchRecordTypeValue},
+		{Value: givenBlob, Type: WriteBatchRecordTypeLogData},
+		{CF: 1, Key: givenKey1, Value: givenVal1, Type: WriteBatchRecordTypeMerge},
+		{CF: 1, Key: givenKey2, Type: WriteBatchRecordTypeDeletion},
+		{CF: 1, Key: givenKey2, Type: WriteBatchRecordTypeSingleDeletion},
+		{CF: 1, Key: givenStart, Value: givenEnd, Type: WriteBatchRecordTypeRangeDeletion},
+		{CF: 0, Key: givenKey2, Value: givenVal1, Type: WriteBatchRecordTypeValue},
+	}
+	iter := wb.NewIterator()
+	for _, record := range expected {
+		ensure.True(t, iter.Next())
+		ensure.DeepEqual(t, *iter.Record(), record)
+	}
+	ensure.False(t, iter.Next())
+	ensure.Nil(t, iter.Error())
+}
+
+func TestWriteBatchIteratorMarkers(t *testing.T) {
+	// a batch header followed by the markers of a prepared transaction
+	data := append(make([]byte, 12),
+		0x9,
+		0xA, 3, 't', 'x', 'n',
+		0xB, 3, 't', 'x', 'n',
+		0xC, 3, 't', 'x', 'n',
+		0xD)
+	wb := WriteBatchFrom(data)
+	defer wb.Release()
+
+	expected := []WriteBatchRecord{
+		{Type: WriteBatchRecordTypeBeginPrepareXID},
+		{Value: []byte("txn"), Type: WriteBatchRecordTypeEndPrepareXID},
+		{Value: []byte("txn"), Type: WriteBatchRecordTypeCommitXID},
+		{Value: []byte("txn"), Type: WriteBatchRecordTypeRollbackXID},
+		{Type: WriteBatchRecordTypeNoop},
+	}
+	iter := wb.NewIterator()
+	for _, record := range expected {
+		ensure.True(t, iter.Next())
+		ensure.DeepEqual(t, *iter.Record(), record)
+	}
+	ensure.False(t, iter.Next())
+	ensure.Nil(t, iter.Error())
+}
+
+func TestWriteBatchIteratorUnknownType(t *testing.T) {
+	// a batch header followed by a record with an unassigned tag
+	data := append(make([]byte, 12), 0x60)
+	wb := WriteBatchFrom(data)
+	defer wb.Release()
+
+	iter := wb.NewIterator()
+	ensure.False(t, iter.Next())
+	ensure.DeepEqual(t, iter.Error(), &UnknownRecordTypeError{Type: 0x60})
+}
+
+func TestWriteBatchIteratorCFOverflow(t *testing.T) {
+	// a batch header followed by a put into column family 1<<32
+	data := append(make([]byte, 12), 0x5, 0x80, 0x80, 0x80, 0x80, 0x10)
+	wb := WriteBatchFrom(data)
+	defer wb.Release()
+
+	iter := wb.NewIterator()
+	ensure.False(t, iter.Next())
+	ensure.NotNil(t, iter.Error())
+	ensure.True(t, iter.Error() != io.ErrShortBuffer)
+}